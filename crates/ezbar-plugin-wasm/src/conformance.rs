@@ -0,0 +1,361 @@
+//! The cross-SDK lowering corpus (`wit/conformance/lower.txt`), shared with the
+//! Go SDK: each case's render, built with our builders, must [`lower`] to
+//! exactly the arena written under it.
+
+use super::widget::*;
+use super::*;
+
+const CORPUS: &str = include_str!("../../../wit/conformance/lower.txt");
+
+const ICONS: [Icon; 40] = [
+    Icon::Cpu,
+    Icon::Memory,
+    Icon::Temperature,
+    Icon::Ping,
+    Icon::VolumeHigh,
+    Icon::VolumeMedium,
+    Icon::VolumeMute,
+    Icon::Battery,
+    Icon::BatteryCharging,
+    Icon::BatteryWarning,
+    Icon::Bot,
+    Icon::Github,
+    Icon::Spotify,
+    Icon::Kubernetes,
+    Icon::Clock,
+    Icon::Calendar,
+    Icon::Disk,
+    Icon::Net,
+    Icon::Ip,
+    Icon::Updates,
+    Icon::Keyboard,
+    Icon::Cloud,
+    Icon::Sun,
+    Icon::Moon,
+    Icon::Alert,
+    Icon::Dot,
+    Icon::CloudSun,
+    Icon::CloudMoon,
+    Icon::CloudFog,
+    Icon::CloudDrizzle,
+    Icon::CloudRain,
+    Icon::CloudRainWind,
+    Icon::CloudSnow,
+    Icon::CloudHail,
+    Icon::CloudLightning,
+    Icon::Droplets,
+    Icon::Wind,
+    Icon::Sunrise,
+    Icon::Sunset,
+    Icon::Snowflake,
+];
+
+const TOKENS: [Token; 7] = [
+    Token::Fg,
+    Token::FgDim,
+    Token::Accent,
+    Token::Ok,
+    Token::Warn,
+    Token::Urgent,
+    Token::Bg,
+];
+
+const GRAPH_KINDS: [GraphKind; 5] = [
+    GraphKind::Cpu,
+    GraphKind::Memory,
+    GraphKind::Temperature,
+    GraphKind::Ping,
+    GraphKind::Generic,
+];
+
+/// The WIT name of a fieldless variant: `CloudSun` → `cloud-sun`.
+fn kebab(v: impl std::fmt::Debug) -> String {
+    let mut s = String::new();
+    for (i, c) in format!("{v:?}").chars().enumerate() {
+        if c.is_ascii_uppercase() && i > 0 {
+            s.push('-');
+        }
+        s.push(c.to_ascii_lowercase());
+    }
+    s
+}
+
+fn by_name<T: Copy + std::fmt::Debug>(all: &[T], name: &str) -> T {
+    *all.iter()
+        .find(|v| kebab(v) == name)
+        .unwrap_or_else(|| panic!("unknown name {name:?}"))
+}
+
+fn paint(p: &Paint) -> String {
+    match p {
+        Paint::Token(t) => kebab(t),
+        Paint::Rgba(r, g, b, a) => format!("#{r:02x}{g:02x}{b:02x}{a:02x}"),
+    }
+}
+
+fn nums(vs: &[f64]) -> String {
+    let s: Vec<String> = vs.iter().map(|v| v.to_string()).collect();
+    format!("[{}]", s.join(" "))
+}
+
+fn layout(kind: &str, children: &[u32], spacing: f32, align: Align) -> String {
+    let kids: Vec<String> = children.iter().map(|c| c.to_string()).collect();
+    format!(
+        "{kind} [{}] spacing={spacing} align={}",
+        kids.join(" "),
+        kebab(align)
+    )
+}
+
+/// Every field of a lowered tree, in the corpus format.
+fn wire_lines(nodes: &[WireNode], root: u32) -> Vec<String> {
+    let mut lines: Vec<String> = nodes
+        .iter()
+        .map(|n| match n {
+            WireNode::Text {
+                content,
+                color,
+                size,
+            } => {
+                let size = size.map_or("none".to_string(), |s| s.to_string());
+                format!("text {content:?} {} size={size}", paint(color))
+            }
+            WireNode::Row {
+                children,
+                spacing,
+                align,
+            } => layout("row", children, *spacing, *align),
+            WireNode::Column {
+                children,
+                spacing,
+                align,
+            } => layout("column", children, *spacing, *align),
+            WireNode::Container { child, padding } => {
+                format!("container {child} padding={padding}")
+            }
+            WireNode::MouseArea { child, id } => format!("mouse-area {child} {id:?}"),
+            WireNode::Icon { id, color, size } => {
+                format!("icon {}:{} {size} {}", *id as u32, kebab(id), paint(color))
+            }
+            WireNode::Graph { values, kind, line } => {
+                format!("graph {} {} {}", kebab(kind), paint(line), nums(values))
+            }
+            WireNode::Chart {
+                values,
+                line,
+                width,
+                height,
+            } => format!("chart {} {width}x{height} {}", paint(line), nums(values)),
+            WireNode::Spacer(px) => format!("spacer {px}"),
+        })
+        .collect();
+    lines.push(format!("root {root}"));
+    lines
+}
+
+/// Builds a case's description with the public builders, leaving defaulted
+/// attributes to them.
+struct Parser<'a> {
+    s: &'a str,
+}
+
+impl<'a> Parser<'a> {
+    fn eat(&mut self, prefix: &str) -> bool {
+        match self.s.strip_prefix(prefix) {
+            Some(rest) => {
+                self.s = rest;
+                true
+            }
+            None => false,
+        }
+    }
+
+    /// Up to the next delimiter.
+    fn word(&mut self) -> &'a str {
+        let i = self
+            .s
+            .find(['(', ')', '{', '}', ',', '[', ' '])
+            .unwrap_or(self.s.len());
+        let (w, rest) = self.s.split_at(i);
+        self.s = rest;
+        w
+    }
+
+    /// A quoted string (the corpus only escapes `"` and `\`), or a `[..]` list,
+    /// or a bare word.
+    fn arg(&mut self) -> String {
+        if self.s.starts_with('"') {
+            let mut escaped = false;
+            for (i, c) in self.s.char_indices().skip(1) {
+                match c {
+                    '\\' if !escaped => escaped = true,
+                    '"' if !escaped => {
+                        let (q, rest) = self.s.split_at(i + 1);
+                        self.s = rest;
+                        return q.to_string();
+                    }
+                    _ => escaped = false,
+                }
+            }
+            panic!("unterminated string");
+        }
+        if self.s.starts_with('[') {
+            let i = self.s.find(']').expect("unclosed [");
+            let (list, rest) = self.s.split_at(i + 1);
+            self.s = rest;
+            return list.to_string();
+        }
+        self.word().to_string()
+    }
+
+    fn args(&mut self) -> Vec<String> {
+        let mut args = Vec::new();
+        if !self.eat("(") {
+            return args;
+        }
+        loop {
+            args.push(self.arg());
+            if self.eat(")") {
+                return args;
+            }
+            assert!(self.eat(","), "want , or ) at {:?}", self.s);
+        }
+    }
+
+    fn kids(&mut self) -> Vec<Render> {
+        assert!(self.eat("{"), "want {{ at {:?}", self.s);
+        let mut kids = Vec::new();
+        loop {
+            self.eat(" ");
+            if self.eat("}") {
+                return kids;
+            }
+            kids.push(self.render());
+        }
+    }
+
+    fn render(&mut self) -> Render {
+        let kind = self.word();
+        let mut pos = Vec::new();
+        let mut kw = std::collections::HashMap::new();
+        for a in self.args() {
+            match a.split_once('=') {
+                Some((k, v)) if !a.starts_with('"') => {
+                    kw.insert(k.to_string(), v.to_string());
+                }
+                _ => pos.push(a),
+            }
+        }
+        let num = |s: &str| -> f32 { s.parse().unwrap_or_else(|_| panic!("bad number {s:?}")) };
+        let kwnum = |k: &str| kw.get(k).map(|v| num(v));
+        match kind {
+            "text" => {
+                let mut r = text(unquote(&pos[0]));
+                let c = color(&pos[1]);
+                if c != Paint::Token(Token::Fg) {
+                    r = r.color(c);
+                }
+                if let Some(px) = kwnum("size") {
+                    r = r.size(px);
+                }
+                r
+            }
+            "row" | "column" => {
+                let kids = self.kids();
+                let mut r = if kind == "row" {
+                    row(kids)
+                } else {
+                    column(kids)
+                };
+                if let Some(px) = kwnum("spacing") {
+                    r = r.spacing(px);
+                }
+                if let Some(a) = kw.get("align") {
+                    r = r.align(by_name(&[Align::Start, Align::Center, Align::End], a));
+                }
+                r
+            }
+            "container" => {
+                let mut kids = self.kids();
+                assert_eq!(kids.len(), 1, "container wants one child");
+                let mut r = container(kids.remove(0));
+                if let Some(px) = kwnum("padding") {
+                    r = r.padding(px);
+                }
+                r
+            }
+            "mouse-area" => {
+                let mut kids = self.kids();
+                assert_eq!(kids.len(), 1, "mouse-area wants one child");
+                mouse_area(unquote(&pos[0]), kids.remove(0))
+            }
+            "icon" => by_name(&ICONS, &pos[0]).view(num(&pos[1]), color(&pos[2])),
+            "graph" => Graph {
+                values: values(&pos[2]),
+                kind: by_name(&GRAPH_KINDS, &pos[0]),
+                line: color(&pos[1]),
+            }
+            .view(),
+            "chart" => {
+                let (w, h) = pos[1].split_once('x').expect("chart wants <w>x<h>");
+                Chart {
+                    values: values(&pos[2]),
+                    line: color(&pos[0]),
+                    width: num(w),
+                    height: num(h),
+                }
+                .view()
+            }
+            "spacer" => spacer(num(&pos[0])),
+            other => panic!("unknown node {other:?}"),
+        }
+    }
+}
+
+fn unquote(s: &str) -> String {
+    let inner = &s[1..s.len() - 1];
+    inner.replace("\\\"", "\"").replace("\\\\", "\\")
+}
+
+fn color(s: &str) -> Paint {
+    match s.strip_prefix('#') {
+        Some(hex) => {
+            let v = u32::from_str_radix(hex, 16).unwrap_or_else(|_| panic!("bad paint {s:?}"));
+            assert_eq!(hex.len(), 8, "bad paint {s:?}");
+            let [r, g, b, a] = v.to_be_bytes();
+            Paint::Rgba(r, g, b, a)
+        }
+        None => Paint::Token(by_name(&TOKENS, s)),
+    }
+}
+
+fn values(s: &str) -> Vec<f64> {
+    s.trim_matches(['[', ']'])
+        .split_whitespace()
+        .map(|v| v.parse().unwrap_or_else(|_| panic!("bad number {v:?}")))
+        .collect()
+}
+
+#[test]
+fn lowering_matches_corpus() {
+    let mut cases: Vec<(&str, Vec<&str>)> = Vec::new();
+    for line in CORPUS.lines() {
+        if let Some(desc) = line.strip_prefix("> ") {
+            cases.push((desc, Vec::new()));
+        } else if !line.is_empty() && !line.starts_with('#') {
+            cases
+                .last_mut()
+                .expect("node line before any case")
+                .1
+                .push(line);
+        }
+    }
+    assert!(!cases.is_empty(), "no cases in the corpus");
+    for (desc, want) in cases {
+        let mut p = Parser { s: desc };
+        let r = p.render();
+        assert!(p.s.is_empty(), "{desc}: trailing {:?}", p.s);
+        let (nodes, root) = lower(&r);
+        assert_eq!(wire_lines(&nodes, root), want, "{desc}");
+    }
+}
@@ -644,6 +644,9 @@ pub mod prelude {
     };
 }
 
+#[cfg(test)]
+mod conformance;
+
 #[cfg(test)]
 mod tests {
     use super::*;
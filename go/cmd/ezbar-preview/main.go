@@ -0,0 +1,132 @@
+// Command ezbar-preview runs a Go plugin natively on the simulated host and
+// draws its chip and popup in the terminal — no wasm build, no GPU, fine over
+// SSH:
+//
+//	go run ./cmd/ezbar-preview ./examples/loadgauge
+//	go run ./cmd/ezbar-preview -config city=Berlin -feeds cpu,memory ./examples/weather
+//	go run ./cmd/ezbar-preview -for 30s -no-color ./examples/loadgauge   # one frame, for CI
+//
+// It builds the plugin package as-is, plus one overlaid file whose init hands
+// the registered plugin to package preview (go/ezbar/preview). Keys: 1-9
+// press a mouse area, tab selects one, enter/space press it, r right-presses,
+// ↑/↓ (or k/j) scroll, e/l enter/leave, p toggles the popup, q quits.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/birdayz/ezbar/go/ezbar/preview"
+)
+
+// hook runs the preview after the package's own init (and its Register): the
+// go command hands files to the compiler sorted by name.
+const hook = `//go:build !wasm
+
+package main
+
+import "github.com/birdayz/ezbar/go/ezbar/preview"
+
+func init() { preview.Main() }
+`
+
+// configFlag collects repeated -config key=value pairs.
+type configFlag map[string]string
+
+func (c configFlag) String() string { return fmt.Sprint(map[string]string(c)) }
+
+func (c configFlag) Set(kv string) error {
+	k, v, ok := strings.Cut(kv, "=")
+	if !ok {
+		return errors.New("want key=value")
+	}
+	c[k] = v
+	return nil
+}
+
+func main() {
+	config := configFlag{}
+	flag.Var(config, "config", "a `key=value` of the [modules.<id>] table (repeatable)")
+	feeds := flag.String("feeds", "", "comma-separated feeds to grant with synthetic data (cpu,memory,temperature,ping,battery,net)")
+	popup := flag.Bool("popup", false, "show the popup from the start")
+	noColor := flag.Bool("no-color", os.Getenv("NO_COLOR") != "", "plain text, no ANSI colours")
+	runFor := flag.Duration("for", 0, "run this long on the virtual clock, print one frame and exit")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: ezbar-preview [flags] [package]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	pkg := "."
+	switch flag.NArg() {
+	case 0:
+	case 1:
+		pkg = flag.Arg(0)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	o := preview.Options{Config: config, Popup: *popup, Color: !*noColor, For: *runFor}
+	if *feeds != "" {
+		o.Feeds = strings.Split(*feeds, ",")
+	}
+	if err := run(pkg, o); err != nil {
+		fmt.Fprintln(os.Stderr, "ezbar-preview:", err)
+		var exit *exec.ExitError
+		if errors.As(err, &exit) {
+			os.Exit(exit.ExitCode())
+		}
+		os.Exit(1)
+	}
+}
+
+// run builds and runs pkg with the preview hook overlaid into it.
+func run(pkg string, o preview.Options) error {
+	out, err := exec.Command("go", "list", "-f", "{{.Name}} {{.Dir}}", pkg).Output()
+	if err != nil {
+		var exit *exec.ExitError
+		if errors.As(err, &exit) {
+			os.Stderr.Write(exit.Stderr)
+		}
+		return fmt.Errorf("go list %s: %w", pkg, err)
+	}
+	name, dir, _ := strings.Cut(strings.TrimSpace(string(out)), " ")
+	if name != "main" {
+		return fmt.Errorf("%s is package %s, not a plugin's package main", pkg, name)
+	}
+
+	tmp, err := os.MkdirTemp("", "ezbar-preview")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	hookFile := filepath.Join(tmp, "hook.go")
+	if err := os.WriteFile(hookFile, []byte(hook), 0o644); err != nil {
+		return err
+	}
+	overlay, err := json.Marshal(map[string]any{"Replace": map[string]string{
+		filepath.Join(dir, "zz_ezbar_preview.go"): hookFile,
+	}})
+	if err != nil {
+		return err
+	}
+	overlayFile := filepath.Join(tmp, "overlay.json")
+	if err := os.WriteFile(overlayFile, overlay, 0o644); err != nil {
+		return err
+	}
+	env, err := json.Marshal(o)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("go", "run", "-overlay", overlayFile, pkg)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = append(os.Environ(), preview.Env+"="+string(env))
+	return cmd.Run()
+}
@@ -1,7 +1,9 @@
 // A minimal ezbar Go plugin: a clock chip. Build with:
 //
-//	tinygo build -target=wasip2 -o clock.wasm \
-//	    --wit-package ../../wit --wit-world plugin-guest .
+//	tinygo build -target=wasip2 -tags ezbar_wit_v5 -o clock.wasm \
+//	    --wit-package ../../wit --wit-world plugin-guest-v5 .
+//
+// (v0.5.0 is the oldest WIT with local-timezone, which ezbar.Local needs.)
 //
 // The whole plugin is a Plugin impl + the Register call below.
 package main
@@ -21,14 +23,15 @@ func (c *Clock) Update(ctx ezbar.Ctx, ev ezbar.Event) bool {
 	if ev.Kind != ezbar.EvTimer {
 		return false
 	}
-	c.now = time.Now().Format("15:04")
+	// the sandbox's time.Local is UTC; ezbar.Local() is the user's zone
+	c.now = time.Now().In(ezbar.Local()).Format("15:04")
 	ctx.SetTimeout(10_000) // tick again in 10s
 	return true
 }
 
 func (c *Clock) View() ezbar.Render {
 	return ezbar.Row(
-		ezbar.IconClock.View(14, ezbar.FgDim),
+		ezbar.IconClock.View(ezbar.Em(1), ezbar.FgDim),
 		ezbar.Text(c.now).Color(ezbar.Fg),
 	).Spacing(5)
 }
@@ -8,8 +8,8 @@
 //
 // Build:
 //
-//	tinygo build -target=wasip2 -o loadgauge.wasm \
-//	    --wit-package ../../wit --wit-world plugin-guest .
+//	tinygo build -target=wasip2 -tags ezbar_wit_v1 -o loadgauge.wasm \
+//	    --wit-package ../../wit --wit-world plugin-guest-v1 .
 package main
 
 import (
@@ -100,7 +100,7 @@ func (g *LoadGauge) View() ezbar.Render {
 	}
 
 	chip := ezbar.Row(
-		ezbar.IconCPU.View(14, ezbar.FgDim),
+		ezbar.IconCPU.View(ezbar.Em(1), ezbar.FgDim),
 		body,
 	).Spacing(6)
 
@@ -0,0 +1,20 @@
+package ezbar
+
+import (
+	"strconv"
+	"time"
+)
+
+// clock is where the SDK's own timer bookkeeping ([Components]) reads the
+// time: the system clock, or a native build's [UseClock].
+var clock = time.Now
+
+// now reads clock, tracing the reading under [Record] so a replay sees the
+// same times.
+func now() time.Time {
+	t := clock()
+	if r, ok := exportCtx.(recorder); ok {
+		r.t.emit("clock", strconv.FormatInt(t.UnixNano(), 10))
+	}
+	return t
+}
@@ -0,0 +1,230 @@
+package ezbar
+
+import (
+	"strings"
+	"time"
+)
+
+// Component is a self-contained piece of a chip with its own Elm loop — a
+// weather readout, a deploy status, an on-call badge — that a Plugin nests
+// with [Components]. Its methods mean what the Plugin's do; it has no Load
+// (the parent reads the config and builds its children from it) and no saved
+// state of its own.
+type Component interface {
+	Update(ctx Ctx, ev Event) bool
+	View() Render
+	Popup() (tree Render, ok bool)
+}
+
+// Components runs a Plugin's child Components, each under a name, as though
+// each were a plugin of its own:
+//
+//	type Dash struct {
+//		ezbar.Base
+//		kids ezbar.Components
+//	}
+//
+//	func (d *Dash) Load(config map[string]string) {
+//		d.kids.Add("weather", weather.New(config["city"]))
+//		d.kids.Add("oncall", oncall.New(config["rota"]))
+//	}
+//
+//	func (d *Dash) Update(ctx ezbar.Ctx, ev ezbar.Event) bool { return d.kids.Update(ctx, ev) }
+//
+//	func (d *Dash) View() ezbar.Render {
+//		return ezbar.Row(d.kids.View("weather"), d.kids.View("oncall")).Spacing(8)
+//	}
+//
+// Update routes each event: a pointer event to the child whose MouseArea id
+// it carries (View and Popup prefix a child's ids with its name and a /), a
+// feed sample to the children that subscribed to that feed, a timer to the
+// children whose SetTimeout is due, and anything else to every child. The
+// children's SetTimeout calls, and their named timers (After, Every and
+// Stop), are merged into the one host timer, armed for the earliest; a child
+// that has never called SetTimeout gets every timer the parent does, the
+// bootstrap one included. FeedSubscribe is forwarded with the shortest period
+// any child asked for. The parent leaves both calls to Components while it
+// has children.
+//
+// Components nest: a Component can run its own.
+type Components struct {
+	kids  []*child
+	armed time.Time           // the host timer's deadline; zero if none is pending
+	feeds map[FeedKind]uint32 // the shortest period asked for, by feed
+}
+
+type child struct {
+	name  string
+	c     Component
+	timer childTimer
+	due   time.Time // when timer is childArmed
+	feeds map[FeedKind]bool
+	named Timers // the child's own After/Every timers
+}
+
+type childTimer uint8
+
+const (
+	childUnarmed childTimer = iota // never called SetTimeout: every timer
+	childArmed                     // a SetTimeout pending until due
+	childIdle                      // cancelled, or fired and not re-armed
+)
+
+// minTimeout is the host's floor on a nonzero SetTimeout.
+const minTimeout = 100 * time.Millisecond
+
+// timerSlack is how early a deadline may still count as due: the host times
+// its timer on its own clock, which can run a hair ahead of the plugin's.
+const timerSlack = 5 * time.Millisecond
+
+// Add adds c under name, replacing any child of that name — and with it the
+// old child's timers and feed subscriptions. The name prefixes c's MouseArea
+// ids, so keep it short.
+func (cs *Components) Add(name string, c Component) {
+	for i, k := range cs.kids {
+		if k.name == name {
+			cs.kids[i] = &child{name: name, c: c}
+			return
+		}
+	}
+	cs.kids = append(cs.kids, &child{name: name, c: c})
+}
+
+// Get is the child under name, or nil.
+func (cs *Components) Get(name string) Component {
+	if k := cs.child(name); k != nil {
+		return k.c
+	}
+	return nil
+}
+
+func (cs *Components) child(name string) *child {
+	for _, k := range cs.kids {
+		if k.name == name {
+			return k
+		}
+	}
+	return nil
+}
+
+// Update routes ev to the children it's for, then arms the host timer for
+// the earliest of theirs; it reports whether any of them asked to re-render.
+func (cs *Components) Update(ctx Ctx, ev Event) bool {
+	t := now()
+	if ev.Kind == EvTimer {
+		cs.armed = time.Time{} // the host's timer is one-shot
+	}
+	redraw := false
+	for _, k := range cs.kids {
+		e, ok := k.routes(ev, t)
+		if !ok {
+			continue
+		}
+		if ev.Kind == EvTimer && k.timer == childArmed {
+			k.timer = childIdle // consumed on fire, as on the host
+		}
+		redraw = k.named.Update(childCtx{Ctx: ctx, cs: cs, k: k, now: t}, e, k.c.Update) || redraw
+	}
+	cs.arm(ctx, t)
+	return redraw
+}
+
+// routes is ev as k sees it, if k should see it at all.
+func (k *child) routes(ev Event, t time.Time) (Event, bool) {
+	switch ev.Kind {
+	case EvPointer:
+		id, ok := strings.CutPrefix(ev.PointerID, k.name+"/")
+		ev.PointerID = id
+		return ev, ok
+	case EvFeed:
+		return ev, k.feeds[ev.Feed]
+	case EvTimer:
+		return ev, k.timer == childUnarmed || k.timer == childArmed && !k.due.After(t.Add(timerSlack))
+	}
+	return ev, true
+}
+
+// arm points the host timer at the earliest armed child's deadline, or
+// cancels it if none is armed, unless it's there already.
+func (cs *Components) arm(ctx Ctx, t time.Time) {
+	var next time.Time
+	for _, k := range cs.kids {
+		if k.timer == childArmed && (next.IsZero() || k.due.Before(next)) {
+			next = k.due
+		}
+	}
+	if next.Equal(cs.armed) {
+		return
+	}
+	cs.armed = next
+	if next.IsZero() {
+		ctx.SetTimeout(0)
+		return
+	}
+	ctx.SetTimeout(timeoutMs(next.Sub(t)))
+}
+
+// View is the child's chip, its MouseArea ids prefixed with its name, or an
+// empty Render if there's no child of that name.
+func (cs *Components) View(name string) Render {
+	k := cs.child(name)
+	if k == nil {
+		return Render{}
+	}
+	return k.scope(k.c.View())
+}
+
+// Popup is the child's popup, its ids prefixed as View's are.
+func (cs *Components) Popup(name string) (Render, bool) {
+	k := cs.child(name)
+	if k == nil {
+		return Render{}, false
+	}
+	tree, ok := k.c.Popup()
+	return k.scope(tree), ok
+}
+
+// scope prefixes the MouseArea ids in r with k's name; OnClick areas route
+// themselves and are left alone.
+func (k *child) scope(r Render) Render {
+	r, _ = rewrite(r, func(n *Render) bool {
+		if n.kind != kMouseArea || n.onPtr != nil {
+			return false
+		}
+		n.hitID = k.name + "/" + n.hitID
+		return true
+	})
+	return r
+}
+
+// childCtx is the Ctx a child sees: the parent's, with SetTimeout and
+// FeedSubscribe kept per child.
+type childCtx struct {
+	Ctx
+	cs  *Components
+	k   *child
+	now time.Time
+}
+
+func (c childCtx) SetTimeout(ms uint32) {
+	if ms == 0 {
+		c.k.timer = childIdle
+		return
+	}
+	c.k.timer, c.k.due = childArmed, c.now.Add(max(time.Duration(ms)*time.Millisecond, minTimeout))
+}
+
+func (c childCtx) FeedSubscribe(feed FeedKind, minPeriodMs uint32) {
+	if c.k.feeds == nil {
+		c.k.feeds = map[FeedKind]bool{}
+	}
+	c.k.feeds[feed] = true
+	if c.cs.feeds == nil {
+		c.cs.feeds = map[FeedKind]uint32{}
+	}
+	if p, ok := c.cs.feeds[feed]; ok && p <= minPeriodMs {
+		return
+	}
+	c.cs.feeds[feed] = minPeriodMs
+	c.Ctx.FeedSubscribe(feed, minPeriodMs)
+}
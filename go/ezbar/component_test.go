@@ -0,0 +1,172 @@
+//go:build !wasm
+
+package ezbar
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// poller is a Component that re-arms every every ms (cancelling, if 0) unless
+// it's unarmed, follows a feed if asked and counts what reaches it.
+type poller struct {
+	unarmed bool
+	every   uint32
+	feed    bool
+	ticks   int
+	clicks  []string
+	samples int
+}
+
+func (p *poller) Update(ctx Ctx, ev Event) bool {
+	switch ev.Kind {
+	case EvTimer:
+		p.ticks++
+		if !p.unarmed {
+			ctx.SetTimeout(p.every)
+		}
+		if p.feed {
+			ctx.FeedSubscribe(FeedCPU, 1000*p.every)
+		}
+		return true
+	case EvPointer:
+		p.clicks = append(p.clicks, ev.PointerID)
+		return true
+	case EvFeed:
+		p.samples++
+	}
+	return false
+}
+
+func (p *poller) View() Render {
+	return MouseArea("btn", OnClick(Text("x"), func(PointerKind, float32) bool { return false }))
+}
+
+func (p *poller) Popup() (Render, bool) { return Row(MouseArea("more", Text("…"))), true }
+
+// feedHost records the host timer and feed subscriptions.
+type feedHost struct {
+	stubHost
+	timeouts []uint32
+	periods  []uint32
+}
+
+func (h *feedHost) SetTimeout(ms uint32) { h.timeouts = append(h.timeouts, ms) }
+func (h *feedHost) FeedSubscribe(_ FeedKind, minPeriod uint32) {
+	h.periods = append(h.periods, minPeriod)
+}
+
+func TestComponents(t *testing.T) {
+	var at time.Duration
+	UseClock(func() time.Time { return time.Unix(0, 0).Add(at) })
+	defer UseClock(nil)
+
+	slow, fast, idle := &poller{every: 1000, feed: true}, &poller{every: 300, feed: true}, &poller{unarmed: true}
+	var cs Components
+	cs.Add("slow", slow)
+	cs.Add("fast", fast)
+	cs.Add("idle", idle)
+	h := &feedHost{}
+	tick := func(to time.Duration) {
+		at = to
+		cs.Update(h, Event{Kind: EvTimer})
+	}
+
+	tick(0) // the bootstrap reaches everyone
+	for _, to := range []time.Duration{300, 600, 900, 1000, 1200} {
+		tick(to * time.Millisecond)
+	}
+	if slow.ticks != 2 || fast.ticks != 5 || idle.ticks != 6 {
+		t.Errorf("ticks = %d, %d, %d; want 2, 5, 6", slow.ticks, fast.ticks, idle.ticks)
+	}
+	// each wake arms the host for the earliest child: fast at 300, 600, 900,
+	// slow at 1000, fast at 1200, then 1500.
+	if want := []uint32{300, 300, 300, 100, 200, 300}; !reflect.DeepEqual(h.timeouts, want) {
+		t.Errorf("host timeouts = %v, want %v", h.timeouts, want)
+	}
+	// a cancel leaves slow's deadline, at 2000.
+	fast.every, fast.feed = 0, false
+	tick(1500 * time.Millisecond)
+	if got := h.timeouts[len(h.timeouts)-1]; got != 500 || fast.ticks != 6 {
+		t.Errorf("after fast cancels: host timeout = %d, fast ticks = %d; want 500, 6", got, fast.ticks)
+	}
+	tick(2000 * time.Millisecond)
+	if fast.ticks != 6 || slow.ticks != 3 {
+		t.Errorf("after the cancel: ticks = %d, %d; want 6, 3", fast.ticks, slow.ticks)
+	}
+
+	// a feed goes to its subscribers, at the shortest period any asked for.
+	if want := []uint32{1000_000, 300_000}; !reflect.DeepEqual(h.periods, want) {
+		t.Errorf("feed periods = %v, want %v", h.periods, want)
+	}
+	cs.Update(h, Event{Kind: EvFeed, Feed: FeedCPU, Value: 0.5})
+	if slow.samples != 1 || fast.samples != 1 || idle.samples != 0 {
+		t.Errorf("samples = %d, %d, %d; want 1, 1, 0", slow.samples, fast.samples, idle.samples)
+	}
+
+	// pointer ids are scoped by child name.
+	if got := cs.View("fast").String(); got != `mouse-area("fast/btn"){ mouse-area(""){ text("x",fg) } }` {
+		t.Errorf("View(fast) = %s", got)
+	}
+	if tree, ok := cs.Popup("slow"); !ok || tree.String() != `row{ mouse-area("slow/more"){ text("…",fg) } }` {
+		t.Errorf("Popup(slow) = %s, %v", tree, ok)
+	}
+	if !cs.Update(h, Event{Kind: EvPointer, PointerID: "fast/btn"}) || cs.Update(h, Event{Kind: EvPointer, PointerID: "btn"}) {
+		t.Error("a scoped id should reach its child, a bare one nobody")
+	}
+	if len(fast.clicks) != 1 || fast.clicks[0] != "btn" || len(slow.clicks) != 0 {
+		t.Errorf("clicks = %q, %q", fast.clicks, slow.clicks)
+	}
+	if cs.View("nope").String() != "spacer(0)" || cs.Get("idle") != Component(idle) {
+		t.Error("View/Get by name")
+	}
+}
+
+// timed arms a named timer and follows a feed on the bootstrap tick, and
+// records the timers and samples that reach it.
+type timed struct {
+	timers  []string
+	samples int
+}
+
+func (c *timed) Update(ctx Ctx, ev Event) bool {
+	switch ev.Kind {
+	case EvTimer:
+		if len(c.timers) == 0 {
+			ctx.After("x", time.Second)
+			ctx.FeedSubscribe(FeedCPU, 1000)
+		}
+		c.timers = append(c.timers, ev.Timer)
+	case EvFeed:
+		c.samples++
+	}
+	return false
+}
+
+func (*timed) View() Render          { return Text("") }
+func (*timed) Popup() (Render, bool) { return Render{}, false }
+
+// TestComponentsReplace checks a replaced child's timers and feeds don't
+// carry over to the one that replaces it.
+func TestComponentsReplace(t *testing.T) {
+	var at time.Duration
+	UseClock(func() time.Time { return time.Unix(0, 0).Add(at) })
+	defer UseClock(nil)
+
+	var cs Components
+	old, next := &timed{}, &timed{}
+	cs.Add("w", old)
+	h := &feedHost{}
+	cs.Update(h, Event{Kind: EvTimer})
+	cs.Add("w", next)
+	cs.Update(h, Event{Kind: EvFeed, Feed: FeedCPU, Value: 0.5})
+	at = time.Second
+	cs.Update(h, Event{Kind: EvTimer})
+	if len(old.timers) != 1 || old.samples != 0 {
+		t.Errorf("old child: timers %q, %d samples; want just the bootstrap", old.timers, old.samples)
+	}
+	if !reflect.DeepEqual(next.timers, []string{""}) || next.samples != 0 {
+		t.Errorf("new child: timers %q, %d samples; want an unnamed tick only", next.timers, next.samples)
+	}
+}
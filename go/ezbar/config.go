@@ -0,0 +1,488 @@
+package ezbar
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DecodeConfig fills the struct dst points to from the [modules.<id>] table
+// Load receives, field by field, as each field's `ezbar` tag says:
+//
+//	type Config struct {
+//		City     string        `ezbar:"city,default=Berlin"`
+//		Interval time.Duration `ezbar:"interval,default=10m,min=1m"`
+//		Units    string        `ezbar:"units,default=metric,oneof=metric|imperial"`
+//		Alert    float64       `ezbar:"alert_above,min=0,max=100"`
+//		Accent   ezbar.Color   `ezbar:"color,default=accent"`
+//		Stations []string      `ezbar:"stations,default=EDDB,EDDF"`
+//		Token    string        `ezbar:"token,required"`
+//	}
+//
+//	func (w *Weather) Load(config map[string]string) {
+//		w.err = ezbar.DecodeConfig(config, &w.cfg)
+//	}
+//
+// The tag is the key, then options: default=V (used when the key is absent;
+// without one an absent key leaves the field as it was), required, min=N and
+// max=N (for numbers and durations), oneof=a|b|c (for strings, and each
+// item of a string list). A value, default's included, may contain commas,
+// though not one followed by name=. Any other option is an error, and so is
+// a min or max that doesn't suit the field. An untagged exported field is
+// read from its name in snake_case (MinTemp from min_temp); `ezbar:"-"`
+// skips a field.
+//
+// Field types are the string kind, bool (also yes/no, on/off), the int, uint
+// and float kinds, [time.Duration] ("90s", "1h30m", or a bare number of seconds),
+// [Color] (a theme token name — fg, fg-dim, accent, ok, warn, urgent, bg — or
+// #rrggbb / #rrggbbaa), and comma-separated []string, []int and []float64
+// lists. It reads struct tags and sets fields, the reflection TinyGo has, and
+// nothing more.
+//
+// DecodeConfig decodes every field it can and reports every one it can't: the
+// error, if any, is a [ConfigErrors].
+func DecodeConfig(config map[string]string, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return errors.New("ezbar: DecodeConfig needs a pointer to a struct")
+	}
+	v = v.Elem()
+	t := v.Type()
+	var errs ConfigErrors
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue // unexported
+		}
+		tag, ok := sf.Tag.Lookup("ezbar")
+		if tag == "-" {
+			continue
+		}
+		if !ok {
+			tag = snake(sf.Name)
+		}
+		f, bad := parseTag(tag, sf.Type)
+		if bad != "" {
+			return errors.New("ezbar: DecodeConfig: field " + sf.Name + ": " + bad)
+		}
+		raw, present := config[f.key]
+		if !present {
+			if !f.hasDefault {
+				if f.required {
+					errs = append(errs, ConfigError{Key: f.key, Msg: "missing"})
+				}
+				continue
+			}
+			raw = f.def
+		}
+		if msg := setField(v.Field(i), raw, f); msg != "" {
+			errs = append(errs, ConfigError{Key: f.key, Value: raw, Msg: msg})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ConfigError is one config key DecodeConfig couldn't use.
+type ConfigError struct {
+	Key   string
+	Value string // as given ("" if it was missing)
+	Msg   string // what's wrong with it: "missing", "not a number", "above 100", …
+}
+
+func (e ConfigError) Error() string {
+	if e.Value == "" {
+		return e.Key + ": " + e.Msg
+	}
+	return e.Key + " = " + strconv.Quote(e.Value) + ": " + e.Msg
+}
+
+// ConfigErrors is everything wrong with a config table, a key at a time and
+// in field order — short enough to show on an error chip:
+//
+//	var errs ezbar.ConfigErrors
+//	if errors.As(err, &errs) {
+//		return ezbar.Text(errs[0].Error()).Color(ezbar.Urgent)
+//	}
+type ConfigErrors []ConfigError
+
+func (es ConfigErrors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return "config: " + strings.Join(msgs, "; ")
+}
+
+// fieldTag is a parsed `ezbar` tag.
+type fieldTag struct {
+	key        string
+	def        string
+	hasDefault bool
+	required   bool
+	min, max   string
+	oneof      []string
+}
+
+// parseTag splits key,opt=v,… — a segment with no = (other than a flag)
+// continues the previous option's value, so values may hold commas. bad says
+// what's wrong with the tag for a field of type t, if anything: a segment
+// that is neither, or a min or max that isn't a t.
+func parseTag(tag string, t reflect.Type) (f fieldTag, bad string) {
+	parts := strings.Split(tag, ",")
+	f.key = parts[0]
+	var last *string
+	for _, p := range parts[1:] {
+		name, val, ok := strings.Cut(p, "=")
+		switch {
+		case ok && name == "default":
+			f.def, f.hasDefault = val, true
+			last = &f.def
+		case ok && name == "min":
+			f.min, last = val, &f.min
+		case ok && name == "max":
+			f.max, last = val, &f.max
+		case ok && name == "oneof":
+			f.oneof, last = strings.Split(val, "|"), nil
+		case !ok && p == "required":
+			f.required, last = true, nil
+		case !ok && last != nil:
+			*last += "," + p
+		default:
+			return f, "unknown tag option " + strconv.Quote(p)
+		}
+	}
+	for _, b := range [...]struct{ opt, v string }{{"min", f.min}, {"max", f.max}} {
+		if b.v != "" {
+			if msg := checkBound(t, b.v); msg != "" {
+				return f, b.opt + "=" + b.v + ": " + msg
+			}
+		}
+	}
+	return f, ""
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// checkBound says why v can't bound a field of type t, if it can't: a
+// duration's is a duration, a number's (or number list's) a number, and
+// nothing else takes one.
+func checkBound(t reflect.Type, v string) string {
+	if t == durationType {
+		if _, ok := parseDuration(v); !ok {
+			return "not a duration"
+		}
+		return ""
+	}
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return "not a number"
+		}
+		return ""
+	}
+	return "not for a field of type " + t.String()
+}
+
+// setField parses raw into fv, or says why it can't.
+func setField(fv reflect.Value, raw string, f fieldTag) string {
+	raw = strings.TrimSpace(raw)
+	// the common types by pointer, so TinyGo needs no reflection for them
+	switch p := fv.Addr().Interface().(type) {
+	case *string:
+		if msg := f.check(raw); msg != "" {
+			return msg
+		}
+		*p = raw
+		return ""
+	case *[]string:
+		items := splitList(raw)
+		for _, it := range items {
+			if msg := f.check(it); msg != "" {
+				return msg
+			}
+		}
+		*p = items
+		return ""
+	case *[]int:
+		var out []int
+		for _, it := range splitList(raw) {
+			n, msg := f.int(it, strconv.IntSize)
+			if msg != "" {
+				return msg
+			}
+			out = append(out, int(n))
+		}
+		*p = out
+		return ""
+	case *[]float64:
+		var out []float64
+		for _, it := range splitList(raw) {
+			x, msg := f.float(it, 64)
+			if msg != "" {
+				return msg
+			}
+			out = append(out, x)
+		}
+		*p = out
+		return ""
+	case *bool:
+		b, ok := parseBool(raw)
+		if !ok {
+			return "not true or false"
+		}
+		*p = b
+		return ""
+	case *time.Duration:
+		d, msg := f.duration(raw)
+		if msg == "" {
+			*p = d
+		}
+		return msg
+	case *Color:
+		c, ok := parseColor(raw)
+		if !ok {
+			return "not a theme colour or #rrggbb"
+		}
+		*p = c
+		return ""
+	}
+	// named strings, and the other number kinds, named ones included
+	switch fv.Kind() {
+	case reflect.String:
+		if msg := f.check(raw); msg != "" {
+			return msg
+		}
+		fv.SetString(raw)
+		return ""
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, msg := f.int(raw, fv.Type().Bits())
+		if msg == "" {
+			fv.SetInt(n)
+		}
+		return msg
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, msg := f.uint(raw, fv.Type().Bits())
+		if msg == "" {
+			fv.SetUint(n)
+		}
+		return msg
+	case reflect.Float32, reflect.Float64:
+		x, msg := f.float(raw, fv.Type().Bits())
+		if msg == "" {
+			fv.SetFloat(x)
+		}
+		return msg
+	}
+	return "unsupported field type " + fv.Type().String()
+}
+
+// check applies oneof.
+func (f fieldTag) check(s string) string {
+	if len(f.oneof) == 0 {
+		return ""
+	}
+	for _, o := range f.oneof {
+		if s == o {
+			return ""
+		}
+	}
+	return "not one of " + strings.Join(f.oneof, ", ")
+}
+
+func (f fieldTag) int(s string, bits int) (int64, string) {
+	n, err := strconv.ParseInt(s, 10, bits)
+	if err != nil {
+		return 0, numErr(err)
+	}
+	return n, f.bounds(float64(n))
+}
+
+func (f fieldTag) uint(s string, bits int) (uint64, string) {
+	n, err := strconv.ParseUint(s, 10, bits)
+	if err != nil {
+		return 0, numErr(err)
+	}
+	return n, f.bounds(float64(n))
+}
+
+func numErr(err error) string {
+	if errors.Is(err, strconv.ErrRange) {
+		return "out of range"
+	}
+	return "not a whole number"
+}
+
+func (f fieldTag) float(s string, bits int) (float64, string) {
+	x, err := strconv.ParseFloat(s, bits)
+	switch {
+	case errors.Is(err, strconv.ErrRange):
+		return 0, "out of range"
+	case err != nil || x != x:
+		return 0, "not a number"
+	}
+	return x, f.bounds(x)
+}
+
+// bounds applies min and max to a number.
+func (f fieldTag) bounds(x float64) string {
+	if lo, err := strconv.ParseFloat(f.min, 64); err == nil && x < lo {
+		return "below " + f.min
+	}
+	if hi, err := strconv.ParseFloat(f.max, 64); err == nil && x > hi {
+		return "above " + f.max
+	}
+	return ""
+}
+
+func (f fieldTag) duration(s string) (time.Duration, string) {
+	d, ok := parseDuration(s)
+	if !ok {
+		return 0, "not a duration (like 90s or 5m)"
+	}
+	if lo, ok := parseDuration(f.min); ok && d < lo {
+		return 0, "below " + f.min
+	}
+	if hi, ok := parseDuration(f.max); ok && d > hi {
+		return 0, "above " + f.max
+	}
+	return d, ""
+}
+
+// parseDuration is time.ParseDuration, or a bare number of seconds.
+func parseDuration(s string) (time.Duration, bool) {
+	if s == "" {
+		return 0, false
+	}
+	if secs, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(secs * float64(time.Second)), true
+	}
+	d, err := time.ParseDuration(s)
+	return d, err == nil
+}
+
+func parseBool(s string) (bool, bool) {
+	switch strings.ToLower(s) {
+	case "true", "yes", "on", "1":
+		return true, true
+	case "false", "no", "off", "0":
+		return false, true
+	}
+	return false, false
+}
+
+// parseColor reads a theme token name or #rrggbb[aa].
+func parseColor(s string) (Color, bool) {
+	if strings.HasPrefix(s, "#") && (len(s) == 7 || len(s) == 9) {
+		v, err := strconv.ParseUint(s[1:], 16, 32)
+		if err != nil {
+			return Color{}, false
+		}
+		if len(s) == 7 {
+			v = v<<8 | 0xff
+		}
+		return RGBA(uint8(v>>24), uint8(v>>16), uint8(v>>8), uint8(v)), true
+	}
+	name := strings.ReplaceAll(strings.ToLower(s), "_", "-")
+	for _, tok := range themeNames {
+		if tok.name == name {
+			return tok.c, true
+		}
+	}
+	return Color{}, false
+}
+
+// splitList splits a comma list, trimming items and dropping empty ones.
+func splitList(s string) []string {
+	var out []string
+	for _, it := range strings.Split(s, ",") {
+		if it = strings.TrimSpace(it); it != "" {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// snake is a Go field name in snake_case: MinTemp → min_temp, APIKey → api_key.
+func snake(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		upper := r >= 'A' && r <= 'Z'
+		if upper && i > 0 {
+			prevLower := name[i-1] >= 'a' && name[i-1] <= 'z' || name[i-1] >= '0' && name[i-1] <= '9'
+			nextLower := i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z'
+			if prevLower || nextLower && name[i-1] >= 'A' && name[i-1] <= 'Z' {
+				b.WriteByte('_')
+			}
+		}
+		if upper {
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ConfigDiff is what an EvConfig changed in the [modules.<id>] table, by
+// key, each list sorted. A plugin acts on just the keys it cares about:
+//
+//	case ezbar.EvConfig:
+//		w.Load(ev.Config)
+//		if ev.Diff.Touches("city", "units") {
+//			w.fetch(ctx)
+//		}
+type ConfigDiff struct {
+	Added   []string // keys new to the table
+	Removed []string // keys gone from it
+	Changed []string // keys whose value changed
+}
+
+// DiffConfig is how next differs from prev.
+func DiffConfig(prev, next map[string]string) ConfigDiff {
+	var d ConfigDiff
+	for k, v := range next {
+		if old, ok := prev[k]; !ok {
+			d.Added = append(d.Added, k)
+		} else if old != v {
+			d.Changed = append(d.Changed, k)
+		}
+	}
+	for k := range prev {
+		if _, ok := next[k]; !ok {
+			d.Removed = append(d.Removed, k)
+		}
+	}
+	sort.Strings(d.Added)
+	sort.Strings(d.Removed)
+	sort.Strings(d.Changed)
+	return d
+}
+
+// Empty reports whether nothing changed.
+func (d ConfigDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Touches reports whether any of keys was added, removed or changed.
+func (d ConfigDiff) Touches(keys ...string) bool {
+	for _, k := range keys {
+		for _, list := range [][]string{d.Added, d.Removed, d.Changed} {
+			for _, c := range list {
+				if c == k {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package ezbar
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type weatherConfig struct {
+	City     string        `ezbar:"city,default=Berlin"`
+	Interval time.Duration `ezbar:"interval,default=10m,min=1m,max=1h"`
+	Units    string        `ezbar:"units,default=metric,oneof=metric|imperial"`
+	Alert    float64       `ezbar:"alert_above,min=0,max=100"`
+	Color    Color         `ezbar:"color,default=accent"`
+	Stations []string      `ezbar:"stations,default=EDDB, EDDF"`
+	Levels   []int         `ezbar:"levels"`
+	Compact  bool          `ezbar:"compact"`
+	Retries  uint8         `ezbar:"retries,default=3"`
+	MinTemp  float32
+	APIKey   string `ezbar:"-"`
+	secret   string
+}
+
+func TestDecodeConfig(t *testing.T) {
+	var c weatherConfig
+	c.APIKey, c.Compact = "kept", true
+	err := DecodeConfig(map[string]string{
+		"units":       "imperial",
+		"alert_above": " 42.5 ",
+		"color":       "#ff8000",
+		"levels":      "1, 2,,3",
+		"min_temp":    "-3.5",
+		"secret":      "no",
+	}, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := weatherConfig{
+		City: "Berlin", Interval: 10 * time.Minute, Units: "imperial", Alert: 42.5,
+		Color: RGBA(255, 128, 0, 255), Stations: []string{"EDDB", "EDDF"}, Levels: []int{1, 2, 3},
+		Compact: true, Retries: 3, MinTemp: -3.5, APIKey: "kept",
+	}
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("decoded %+v\nwant    %+v", c, want)
+	}
+}
+
+func TestDecodeConfigValues(t *testing.T) {
+	for _, tc := range []struct {
+		key, val string
+		want     any
+	}{
+		{"interval", "90", 90 * time.Second},
+		{"interval", "1h", time.Hour},
+		{"color", "fg-dim", FgDim},
+		{"color", "URGENT", Urgent},
+		{"color", "#10203040", RGBA(0x10, 0x20, 0x30, 0x40)},
+		{"compact", "yes", true},
+		{"compact", "off", false},
+		{"stations", "", []string(nil)},
+	} {
+		var c weatherConfig
+		if err := DecodeConfig(map[string]string{tc.key: tc.val}, &c); err != nil {
+			t.Errorf("%s = %q: %v", tc.key, tc.val, err)
+			continue
+		}
+		var got any
+		switch tc.key {
+		case "interval":
+			got = c.Interval
+		case "color":
+			got = c.Color
+		case "compact":
+			got = c.Compact
+		case "stations":
+			got = c.Stations
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s = %q: got %v, want %v", tc.key, tc.val, got, tc.want)
+		}
+	}
+}
+
+// units is a named string type, decoded by kind.
+type units string
+
+func TestDecodeConfigNamedString(t *testing.T) {
+	var c struct {
+		Units units `ezbar:"units,default=metric,oneof=metric|imperial"`
+	}
+	if err := DecodeConfig(nil, &c); err != nil || c.Units != "metric" {
+		t.Errorf("default: Units = %q, %v; want metric", c.Units, err)
+	}
+	if err := DecodeConfig(map[string]string{"units": "imperial"}, &c); err != nil || c.Units != "imperial" {
+		t.Errorf("Units = %q, %v; want imperial", c.Units, err)
+	}
+	err := DecodeConfig(map[string]string{"units": "kelvin"}, &c)
+	if err == nil || err.Error() != `config: units = "kelvin": not one of metric, imperial` {
+		t.Errorf("err = %v, want oneof to apply", err)
+	}
+}
+
+func TestDecodeConfigBadTag(t *testing.T) {
+	for _, dst := range []any{
+		&struct {
+			City string `ezbar:"city,default=Berlin,foo=bar"`
+		}{},
+		&struct {
+			City string `ezbar:"city,requird"`
+		}{},
+		&struct {
+			Count int `ezbar:"city,min=1m"`
+		}{},
+		&struct {
+			Every time.Duration `ezbar:"city,max=soon"`
+		}{},
+		&struct {
+			City string `ezbar:"city,max=3"`
+		}{},
+	} {
+		err := DecodeConfig(map[string]string{"city": "Oslo"}, dst)
+		var errs ConfigErrors
+		if err == nil || errors.As(err, &errs) {
+			t.Errorf("%T: err = %v, want an unknown tag option error", dst, err)
+		}
+	}
+}
+
+func TestDecodeConfigErrors(t *testing.T) {
+	var c struct {
+		Interval time.Duration `ezbar:"interval,min=1m"`
+		Units    string        `ezbar:"units,oneof=metric|imperial"`
+		Alert    float64       `ezbar:"alert,max=100"`
+		Ratio    float32       `ezbar:"ratio"`
+		Count    int8          `ezbar:"count"`
+		Levels   []int         `ezbar:"levels"`
+		Color    Color         `ezbar:"color"`
+		Tags     []string      `ezbar:"tags,oneof=a|b"`
+		Token    string        `ezbar:"token,required"`
+		City     string        `ezbar:"city"`
+	}
+	err := DecodeConfig(map[string]string{
+		"interval": "10s",
+		"units":    "kelvin",
+		"alert":    "101",
+		"ratio":    "1e40",
+		"count":    "300",
+		"levels":   "1, 99999999999999999999",
+		"color":    "teal",
+		"tags":     "a,c",
+		"city":     "Oslo",
+	}, &c)
+	var errs ConfigErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("err = %v, want ConfigErrors", err)
+	}
+	want := []string{
+		`interval = "10s": below 1m`,
+		`units = "kelvin": not one of metric, imperial`,
+		`alert = "101": above 100`,
+		`ratio = "1e40": out of range`,
+		`count = "300": out of range`,
+		`levels = "1, 99999999999999999999": out of range`,
+		`color = "teal": not a theme colour or #rrggbb`,
+		`tags = "a,c": not one of a, b`,
+		`token: missing`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("%d errors, want %d: %v", len(errs), len(want), err)
+	}
+	for i, e := range errs {
+		if e.Error() != want[i] {
+			t.Errorf("error %d = %s, want %s", i, e.Error(), want[i])
+		}
+	}
+	if c.City != "Oslo" {
+		t.Errorf("City = %q: the good keys should decode despite the bad", c.City)
+	}
+	if err := DecodeConfig(nil, c); err == nil {
+		t.Error("a non-pointer should be an error")
+	}
+}
+
+func TestDiffConfig(t *testing.T) {
+	d := DiffConfig(
+		map[string]string{"city": "Berlin", "units": "metric", "color": "accent", "old": "x"},
+		map[string]string{"city": "Oslo", "units": "metric", "color": "ok", "new": "y", "also": ""},
+	)
+	want := ConfigDiff{Added: []string{"also", "new"}, Removed: []string{"old"}, Changed: []string{"city", "color"}}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("diff = %+v, want %+v", d, want)
+	}
+	if !d.Touches("units", "city") || d.Touches("units") || d.Empty() {
+		t.Errorf("Touches/Empty wrong for %+v", d)
+	}
+	if d := DiffConfig(nil, map[string]string{}); !d.Empty() {
+		t.Errorf("nil to empty = %+v, want no change", d)
+	}
+}
+
+func TestSnake(t *testing.T) {
+	for in, want := range map[string]string{"City": "city", "MinTemp": "min_temp", "APIKey": "api_key", "Ping2Host": "ping2_host", "URL": "url"} {
+		if got := snake(in); got != want {
+			t.Errorf("snake(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package ezbar
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/birdayz/ezbar/go/internal/ezbar/plugin/ui"
+)
+
+// The cross-SDK lowering corpus, shared with crates/ezbar-plugin-wasm.
+const conformancePath = "../../wit/conformance/lower.txt"
+
+func TestConformance(t *testing.T) {
+	data, err := os.ReadFile(conformancePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := 0
+	var desc string
+	var want []string
+	check := func() {
+		if desc == "" {
+			return
+		}
+		cases++
+		p := descParser{s: desc}
+		r := p.render()
+		if p.err != "" || p.s != "" {
+			t.Errorf("%s: parse error %q at %q", desc, p.err, p.s)
+			return
+		}
+		if got := r.String(); got != desc {
+			t.Errorf("%s: not a canonical dump; String() = %s", desc, got)
+		}
+		got := wireLines(lower(r))
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("%s: lowered to\n%s\nwant\n%s", desc, strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		switch {
+		case strings.HasPrefix(line, "> "):
+			check()
+			desc, want = line[2:], nil
+		case line == "" || strings.HasPrefix(line, "#"):
+		default:
+			want = append(want, line)
+		}
+	}
+	check()
+	if cases == 0 {
+		t.Fatal("no cases in " + conformancePath)
+	}
+}
+
+// wireLines spells out every field of a lowered tree, in the corpus format.
+func wireLines(tree ui.Tree) []string {
+	var lines []string
+	for _, n := range tree.Nodes.Slice() {
+		var l string
+		switch {
+		case n.Text() != nil:
+			x := n.Text()
+			size := "none"
+			if s := x.Size.Some(); s != nil {
+				size = num(*s)
+			}
+			l = "text " + strconv.Quote(x.Content) + " " + colorOf(x.Color).String() + " size=" + size
+		case n.Row() != nil:
+			l = "row " + layoutLine(n.Row())
+		case n.Column() != nil:
+			l = "column " + layoutLine(n.Column())
+		case n.Container() != nil:
+			x := n.Container()
+			l = "container " + strconv.Itoa(int(x.Child)) + " padding=" + num(x.Padding)
+		case n.MouseArea() != nil:
+			x := n.MouseArea()
+			l = "mouse-area " + strconv.Itoa(int(x.Child)) + " " + strconv.Quote(x.ID)
+		case n.Icon() != nil:
+			x := n.Icon()
+			l = "icon " + strconv.Itoa(int(x.ID)) + ":" + x.ID.String() + " " + num(x.Size) + " " + colorOf(x.Color).String()
+		case n.Graph() != nil:
+			x := n.Graph()
+			l = "graph " + x.Kind.String() + " " + colorOf(x.Line).String() + " " + nums(x.Values.Slice())
+		case n.Chart() != nil:
+			x := n.Chart()
+			l = "chart " + colorOf(x.Line).String() + " " + num(x.Width) + "x" + num(x.Height) + " " + nums(x.Values.Slice())
+		case n.Spacer() != nil:
+			l = "spacer " + num(*n.Spacer())
+		}
+		lines = append(lines, l)
+	}
+	return append(lines, "root "+strconv.Itoa(int(tree.Root)))
+}
+
+func layoutLine(l *ui.LayoutNode) string {
+	kids := make([]string, 0, l.Children.Len())
+	for _, c := range l.Children.Slice() {
+		kids = append(kids, strconv.Itoa(int(c)))
+	}
+	return "[" + strings.Join(kids, " ") + "] spacing=" + num(l.Spacing) + " align=" + l.Align.String()
+}
+
+// iconNames maps the WIT names to the SDK's exported constants — written out,
+// so a constant that drifts from the WIT order fails the icon case.
+var iconNames = map[string]Icon{
+	"cpu": IconCPU, "memory": IconMemory, "temperature": IconTemperature, "ping": IconPing,
+	"volume-high": IconVolumeHigh, "volume-medium": IconVolumeMedium, "volume-mute": IconVolumeMute,
+	"battery": IconBattery, "battery-charging": IconBatteryCharging, "battery-warning": IconBatteryWarning,
+	"bot": IconBot, "github": IconGithub, "spotify": IconSpotify, "kubernetes": IconKubernetes,
+	"clock": IconClock, "calendar": IconCalendar, "disk": IconDisk, "net": IconNet, "ip": IconIP,
+	"updates": IconUpdates, "keyboard": IconKeyboard, "cloud": IconCloud, "sun": IconSun,
+	"moon": IconMoon, "alert": IconAlert, "dot": IconDot,
+	"cloud-sun": IconCloudSun, "cloud-moon": IconCloudMoon, "cloud-fog": IconCloudFog,
+	"cloud-drizzle": IconCloudDrizzle, "cloud-rain": IconCloudRain, "cloud-rain-wind": IconCloudRainWind,
+	"cloud-snow": IconCloudSnow, "cloud-hail": IconCloudHail, "cloud-lightning": IconCloudLightning,
+	"droplets": IconDroplets, "wind": IconWind, "sunrise": IconSunrise, "sunset": IconSunset,
+	"snowflake": IconSnowflake,
+}
+
+var tokenNames = map[string]Color{
+	"fg": Fg, "fg-dim": FgDim, "accent": Accent, "ok": OK, "warn": Warn, "urgent": Urgent, "bg": Bg,
+}
+
+var graphKinds = map[string]GraphKind{
+	"cpu": GraphCPU, "memory": GraphMemory, "temperature": GraphTemperature, "ping": GraphPing, "generic": GraphGeneric,
+}
+
+var aligns = map[string]Align{"start": AlignStart, "center": AlignCenter, "end": AlignEnd}
+
+// descParser builds a corpus description with the public builders, leaving
+// defaulted attributes to them.
+type descParser struct {
+	s   string
+	err string
+}
+
+func (p *descParser) fail(msg string) {
+	if p.err == "" {
+		p.err = msg
+	}
+}
+
+func (p *descParser) eat(prefix string) bool {
+	if strings.HasPrefix(p.s, prefix) {
+		p.s = p.s[len(prefix):]
+		return true
+	}
+	return false
+}
+
+// word reads up to the next delimiter.
+func (p *descParser) word() string {
+	i := strings.IndexAny(p.s, "(){},[ ")
+	if i < 0 {
+		i = len(p.s)
+	}
+	w := p.s[:i]
+	p.s = p.s[i:]
+	return w
+}
+
+// args reads a parenthesised, comma-separated argument list, if there is one.
+func (p *descParser) args() []string {
+	if !p.eat("(") {
+		return nil
+	}
+	var args []string
+	for {
+		switch {
+		case strings.HasPrefix(p.s, `"`):
+			q, err := strconv.QuotedPrefix(p.s)
+			if err != nil {
+				p.fail("bad string")
+				return nil
+			}
+			args = append(args, q)
+			p.s = p.s[len(q):]
+		case strings.HasPrefix(p.s, "["):
+			i := strings.IndexByte(p.s, ']')
+			if i < 0 {
+				p.fail("unclosed [")
+				return nil
+			}
+			args = append(args, p.s[:i+1])
+			p.s = p.s[i+1:]
+		default:
+			args = append(args, p.word())
+		}
+		if p.eat(")") {
+			return args
+		}
+		if !p.eat(",") {
+			p.fail("want , or )")
+			return nil
+		}
+	}
+}
+
+func (p *descParser) kids() []Render {
+	if !p.eat("{") {
+		p.fail("want {")
+		return nil
+	}
+	var kids []Render
+	for p.err == "" {
+		p.eat(" ")
+		if p.eat("}") {
+			return kids
+		}
+		kids = append(kids, p.render())
+	}
+	return nil
+}
+
+func (p *descParser) render() Render {
+	kind := p.word()
+	args := p.args()
+	// kw splits the key=value args from the positional ones.
+	kw := map[string]string{}
+	var pos []string
+	for _, a := range args {
+		if k, v, ok := strings.Cut(a, "="); ok && !strings.HasPrefix(a, `"`) {
+			kw[k] = v
+		} else {
+			pos = append(pos, a)
+		}
+	}
+	switch kind {
+	case "text":
+		r := Text(p.str(pos, 0))
+		if c := p.color(pos, 1); c != Fg {
+			r = r.Color(c)
+		}
+		if v, ok := kw["size"]; ok {
+			r = r.Size(p.num(v))
+		}
+		return r
+	case "row", "column":
+		kids := p.kids()
+		r := Row(kids...)
+		if kind == "column" {
+			r = Column(kids...)
+		}
+		if v, ok := kw["spacing"]; ok {
+			r = r.Spacing(p.num(v))
+		}
+		if v, ok := kw["align"]; ok {
+			r = r.Align(aligns[v])
+		}
+		return r
+	case "container":
+		kids := p.kids()
+		if len(kids) != 1 {
+			p.fail("container wants one child")
+			return Render{}
+		}
+		r := Container(kids[0])
+		if v, ok := kw["padding"]; ok {
+			r = r.Padding(p.num(v))
+		}
+		return r
+	case "mouse-area":
+		id := p.str(pos, 0)
+		kids := p.kids()
+		if len(kids) != 1 {
+			p.fail("mouse-area wants one child")
+			return Render{}
+		}
+		return MouseArea(id, kids[0])
+	case "icon":
+		id, ok := iconNames[p.arg(pos, 0)]
+		if !ok {
+			p.fail("unknown icon")
+		}
+		return id.View(p.num(p.arg(pos, 1)), p.color(pos, 2))
+	case "graph":
+		return Graph{Values: p.nums(p.arg(pos, 2)), Kind: graphKinds[p.arg(pos, 0)], Line: p.color(pos, 1)}.View()
+	case "chart":
+		w, h, _ := strings.Cut(p.arg(pos, 1), "x")
+		return Chart{Values: p.nums(p.arg(pos, 2)), Line: p.color(pos, 0), Width: p.num(w), Height: p.num(h)}.View()
+	case "spacer":
+		return Spacer(p.num(p.arg(pos, 0)))
+	}
+	p.fail("unknown node " + strconv.Quote(kind))
+	return Render{}
+}
+
+func (p *descParser) arg(pos []string, i int) string {
+	if i >= len(pos) {
+		p.fail("missing argument")
+		return ""
+	}
+	return pos[i]
+}
+
+func (p *descParser) str(pos []string, i int) string {
+	s, err := strconv.Unquote(p.arg(pos, i))
+	if err != nil {
+		p.fail("bad string")
+	}
+	return s
+}
+
+func (p *descParser) num(s string) float32 {
+	f, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		p.fail("bad number " + strconv.Quote(s))
+	}
+	return float32(f)
+}
+
+func (p *descParser) nums(s string) []float64 {
+	var vs []float64
+	for _, f := range strings.Fields(strings.Trim(s, "[]")) {
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			p.fail("bad number " + strconv.Quote(f))
+		}
+		vs = append(vs, v)
+	}
+	return vs
+}
+
+func (p *descParser) color(pos []string, i int) Color {
+	s := p.arg(pos, i)
+	if c, ok := tokenNames[s]; ok {
+		return c
+	}
+	v, err := strconv.ParseUint(strings.TrimPrefix(s, "#"), 16, 32)
+	if err != nil || len(s) != 9 {
+		p.fail("bad paint " + strconv.Quote(s))
+	}
+	return RGBA(uint8(v>>24), uint8(v>>16), uint8(v>>8), uint8(v))
+}
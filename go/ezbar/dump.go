@@ -0,0 +1,151 @@
+package ezbar
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/birdayz/ezbar/go/internal/ezbar/plugin/types"
+)
+
+// String is a compact, stable dump of the tree, one node after another in the
+// WIT's vocabulary:
+//
+//	row(spacing=5){ icon(clock,14,fg-dim) text("12:34",fg) }
+//
+// Attributes at their default (a row's center align, a zero spacing or
+// padding, a text with no size) are left out. [Render.Dump] is the same with a
+// node per line.
+func (r Render) String() string {
+	var b strings.Builder
+	r.dump(&b, -1)
+	return b.String()
+}
+
+// Dump is [Render.String] with one node per line, children indented by two
+// spaces — the form golden files and diffs want.
+func (r Render) Dump() string {
+	var b strings.Builder
+	r.dump(&b, 0)
+	return b.String()
+}
+
+// dump writes r at indent depth; -1 is the one-line form.
+func (r Render) dump(b *strings.Builder, depth int) {
+	b.WriteString(r.kindName())
+	var args []string
+	switch r.kind {
+	case kText:
+		args = append(args, strconv.Quote(r.text), r.color.String())
+		if r.hasSize {
+			args = append(args, "size="+num(r.size))
+		}
+	case kRow, kColumn:
+		if r.spacing != 0 {
+			args = append(args, "spacing="+num(r.spacing))
+		}
+		if def := (r.kind == kRow && r.align == AlignCenter) || (r.kind == kColumn && r.align == AlignStart); !def {
+			args = append(args, "align="+r.align.String())
+		}
+	case kContainer:
+		if r.padding != 0 {
+			args = append(args, "padding="+num(r.padding))
+		}
+	case kMouseArea:
+		args = append(args, strconv.Quote(r.hitID))
+	case kIcon:
+		args = append(args, r.icon.String(), num(r.isize), r.color.String())
+	case kGraph:
+		args = append(args, r.gkind.String(), r.color.String(), nums(r.values))
+	case kChart:
+		args = append(args, r.color.String(), num(r.width)+"x"+num(r.height), nums(r.values))
+	default:
+		args = append(args, num(r.width))
+	}
+	if len(args) > 0 {
+		b.WriteString("(" + strings.Join(args, ",") + ")")
+	}
+	if r.kind != kRow && r.kind != kColumn && r.kind != kContainer && r.kind != kMouseArea {
+		return
+	}
+	if len(r.kids) == 0 {
+		b.WriteString("{}")
+		return
+	}
+	b.WriteByte('{')
+	for _, k := range r.kids {
+		if depth < 0 {
+			b.WriteByte(' ')
+		} else {
+			b.WriteString("\n" + strings.Repeat("  ", depth+1))
+		}
+		k.dump(b, next(depth))
+	}
+	if depth < 0 {
+		b.WriteString(" }")
+	} else {
+		b.WriteString("\n" + strings.Repeat("  ", depth) + "}")
+	}
+}
+
+func next(depth int) int {
+	if depth < 0 {
+		return depth
+	}
+	return depth + 1
+}
+
+// kindName is the WIT node name of r's kind.
+func (r Render) kindName() string {
+	switch r.kind {
+	case kText:
+		return "text"
+	case kRow:
+		return "row"
+	case kColumn:
+		return "column"
+	case kContainer:
+		return "container"
+	case kMouseArea:
+		return "mouse-area"
+	case kIcon:
+		return "icon"
+	case kGraph:
+		return "graph"
+	case kChart:
+		return "chart"
+	default:
+		return "spacer"
+	}
+}
+
+// String is the icon's WIT name, e.g. "cloud-sun".
+func (id Icon) String() string {
+	if id > IconSnowflake {
+		return "icon#" + strconv.Itoa(int(id))
+	}
+	return types.IconID(id).String()
+}
+
+// String is the theme token's WIT name (e.g. "fg-dim"), or #rrggbbaa for an
+// [RGBA] colour.
+func (c Color) String() string {
+	if !c.isRGBA {
+		return c.tok.String()
+	}
+	const hex = "0123456789abcdef"
+	s := []byte{'#'}
+	for _, v := range [4]uint8{c.rgba.R, c.rgba.G, c.rgba.B, c.rgba.A} {
+		s = append(s, hex[v>>4], hex[v&0xf])
+	}
+	return string(s)
+}
+
+func num(f float32) string { return strconv.FormatFloat(float64(f), 'g', -1, 32) }
+
+func nums(vs []float64) string {
+	s := make([]string, len(vs))
+	for i, v := range vs {
+		s[i] = strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return "[" + strings.Join(s, " ") + "]"
+}
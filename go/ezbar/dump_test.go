@@ -0,0 +1,42 @@
+package ezbar
+
+import "testing"
+
+func TestRenderString(t *testing.T) {
+	for _, tc := range []struct {
+		r    Render
+		want string
+	}{
+		{
+			Row(IconClock.View(14, FgDim), Text("12:34").Color(Fg)).Spacing(5),
+			`row(spacing=5){ icon(clock,14,fg-dim) text("12:34",fg) }`,
+		},
+		{Render{}, `spacer(0)`},
+		{Text("big").Size(18).Color(RGBA(0xff, 0x80, 0, 0xff)), `text("big",#ff8000ff,size=18)`},
+		{Column().Align(AlignEnd), `column(align=end){}`},
+		{
+			Container(MouseArea("chip", Spacer(2.5))).Padding(4),
+			`container(padding=4){ mouse-area("chip"){ spacer(2.5) } }`,
+		},
+		{Graph{Values: []float64{1, 2.5}, Kind: GraphCPU, Line: OK}.View(), `graph(cpu,ok,[1 2.5])`},
+		{Chart{Values: []float64{3}, Line: Accent, Width: 120, Height: 40}.View(), `chart(accent,120x40,[3])`},
+	} {
+		if got := tc.r.String(); got != tc.want {
+			t.Errorf("String() = %s\nwant        %s", got, tc.want)
+		}
+	}
+}
+
+func TestRenderDump(t *testing.T) {
+	r := Row(Column(Text("a"), Text("b")), Spacer(3))
+	want := `row{
+  column{
+    text("a",fg)
+    text("b",fg)
+  }
+  spacer(3)
+}`
+	if got := r.Dump(); got != want {
+		t.Errorf("Dump() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -11,11 +11,17 @@
 //		return false
 //	}
 //	func (c *Clock) View() ezbar.Render {
-//		return ezbar.Row(ezbar.IconClock.View(14, ezbar.Fg), ezbar.Text(c.now)).Spacing(5)
+//		return ezbar.Row(ezbar.IconClock.View(ezbar.Em(1), ezbar.Fg), ezbar.Text(c.now)).Spacing(5)
 //	}
 //	func init() { ezbar.Register(&Clock{}) }
 //	func main()  {}
 //
+// The host loads plugins built against any frozen ezbar:plugin WIT version in its
+// window. The SDK targets the latest by default; build with -tags ezbar_wit_vN
+// (and TinyGo's --wit-world plugin-guest-vN) to target v0.N.0 instead — see
+// [Ctx]. An older version loads on more hosts, and a Ctx method it lacks is a
+// compile error.
+//
 // This is NOT arbitrary iced: there is no canvas/shader/custom widget. The plugin
 // describes intent (text, an icon, a sparkline over your data, a popup); the host
 // owns the look and themes it.
@@ -23,22 +29,47 @@ package ezbar
 
 import (
 	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"time"
 
 	"github.com/birdayz/ezbar/go/internal/ezbar/plugin/events"
-	"github.com/birdayz/ezbar/go/internal/ezbar/plugin/host"
 	"github.com/birdayz/ezbar/go/internal/ezbar/plugin/plugin"
 	"github.com/birdayz/ezbar/go/internal/ezbar/plugin/types"
 	"go.bytecodealliance.org/cm"
 )
 
-// Ctx is the gated host services a plugin may call from [Plugin.Update]. It runs
-// off the GUI thread, so a blocking HTTPGet is fine.
-type Ctx interface {
+// ── host services ───────────────────────────────────────────────────────────
+
+// Ctx is the gated host services a plugin may call from [Plugin.Update]: an
+// alias of the CtxVN for the WIT version this build targets (see wit_v*.go). It
+// runs off the GUI thread, so a blocking HTTPGet is fine.
+//
+// Each ezbar:plugin WIT version only ADDS host calls, so each CtxVN embeds the
+// one before it. Pick the version with a build tag — ezbar_wit_v1 … ezbar_wit_v6
+// for v0.1.0 … v0.6.0, none for the latest — and a Ctx method your version
+// doesn't have is a compile error, not a trap on the user's bar. Target the
+// oldest version that has what you call: it loads on the widest range of hosts.
+
+// CtxV1 is the host services of WIT v0.1.0 (RFC 0006), the frozen baseline.
+type CtxV1 interface {
 	// HTTPGet does a blocking GET. It only works if the user granted the URL's
 	// host via [modules.<id>].network in their config; otherwise it errors.
 	HTTPGet(url string) ([]byte, error)
+	// ReadFile reads a whole file through the host — there is no guest
+	// filesystem handle. The read-file grant it's gated by isn't implemented
+	// yet, so today's hosts deny every call: it's a promise of the ABI, not a
+	// way to read files now. A denied path comes back as an *fs.PathError
+	// wrapping fs.ErrPermission; [FS] adapts this to an fs.FS.
+	ReadFile(path string) ([]byte, error)
 	// Log writes a line to the bar's log (stderr).
 	Log(msg string)
+	// TextSize is the bar's text size in px — the user's text_size, 14 by default.
+	// For sizing a Render in View, where there's no Ctx, use [Em].
+	TextSize() float32
+	// Fg is the bar's primary text colour (normally the [Fg] token itself).
+	Fg() Color
 	// SetTimeout asks the host to deliver the next EvTimer after ms milliseconds.
 	//
 	// One-shot: this schedules exactly ONE timer. To keep a cadence, call it again
@@ -47,8 +78,39 @@ type Ctx interface {
 	// no timer until you arm one again (a purely reactive plugin that only redraws on
 	// pointer/feed events should call this once so it costs zero). Values below 100ms
 	// are floored to 100ms. A plugin that never calls this keeps a legacy ~2s
-	// heartbeat (the zero-config default).
+	// heartbeat (the zero-config default). For several timers at once, see
+	// After and Every.
 	SetTimeout(ms uint32)
+	// After arms the timer called name to fire once, d from now; the
+	// EvTimer it fires has Timer set to name. Arming a timer that's pending
+	// moves it. Any number run at once, on top of SetTimeout's: the SDK keeps
+	// them (see [Timers]) on the one host timer, so they need no newer WIT
+	// version. Like SetTimeout, a delay under 100ms is floored to 100ms.
+	//
+	//	case ezbar.EvTimer:
+	//		switch ev.Timer {
+	//		case "": // the bootstrap tick
+	//			ctx.Every("tick", time.Second)
+	//			ctx.After("refresh", 0)
+	//		case "tick":
+	//			w.left--
+	//		case "refresh":
+	//			w.fetch(ctx)
+	//			ctx.After("refresh", 5*time.Minute)
+	//		}
+	After(name string, d time.Duration)
+	// Every arms the timer called name to fire every d from now on, until
+	// Stop; a tick that comes late doesn't make the next one early.
+	Every(name string, d time.Duration)
+	// Stop cancels the timer called name, if it's pending.
+	Stop(name string)
+	// Subscribe declares which event kinds this plugin handles, so the host can
+	// skip delivering the rest — e.g. Subscribe(EvPointer) for a chip that only
+	// reacts to clicks. It replaces any earlier set. The frozen v0.1.0 call is a
+	// no-op on today's hosts (RFC 0011 defers the filtering), so it's a promise
+	// about what you handle, not a filter to rely on: still ignore kinds you
+	// don't want.
+	Subscribe(kinds ...EventKind)
 	// FeedSubscribe subscribes to a host-sampled system feed; the host then delivers
 	// EvFeed {Feed, Value} no faster than minPeriodMs (clamped to >= 1s).
 	//
@@ -64,6 +126,101 @@ type Ctx interface {
 	FeedSubscribe(feed FeedKind, minPeriodMs uint32)
 }
 
+// CtxV2 is WIT v0.2.0: v0.1.0 + read-only sway state (RFC 0013).
+type CtxV2 interface {
+	CtxV1
+	// SwaySnapshot reads the current sway state — the workspace list + focused
+	// window title (RFC 0013).
+	//
+	// Read-only (there's deliberately no way to drive sway from a plugin) and
+	// capability-gated by [modules.<id>].sway = true; it errors if unset (a
+	// synchronous denial, unlike fire-and-forget feeds). It's a pull get-current:
+	// call it in Update (e.g. on your EvTimer) and render from the result — sway
+	// state is a snapshot, not a stream.
+	SwaySnapshot() (SwayState, error)
+}
+
+// CtxV3 is WIT v0.3.0: v0.2.0 + the exec capability tier (RFC 0015).
+type CtxV3 interface {
+	CtxV2
+	// Exec runs an allow-listed program with args (and stdin, if non-nil) to
+	// completion — the exec capability (RFC 0015), the dangerous tier.
+	//
+	// Gated by [modules.<id>].exec = ["kubectl", …] ("*" = any, or [plugins] yolo);
+	// it errors if the program isn't granted (a synchronous denial, like HTTPGet)
+	// or couldn't be spawned. A non-zero exit is NOT an error: check
+	// ExecOutput.Code. Like HTTPGet, keep it on the timer path — the guest is parked
+	// while the command runs.
+	Exec(program string, args []string, stdin []byte) (ExecOutput, error)
+}
+
+// CtxV4 is WIT v0.4.0: v0.3.0 + the native picker (RFC 0018).
+type CtxV4 interface {
+	CtxV3
+	// Pick opens the bar's native searchable picker over items and blocks until
+	// the user picks one (returns it, true) or dismisses (returns "", false).
+	// current is the index of the item to mark ✓; pass -1 for none.
+	//
+	// The picker — search field, fuzzy filtering, keyboard, focus, theming — is
+	// rendered by the host in iced, so you never reimplement text input (RFC 0018).
+	// Like Exec, the guest parks here while the user interacts, so call it from
+	// Update (an event handler), not View. Needs no capability grant (it reads/runs
+	// nothing).
+	Pick(prompt string, items []string, current int) (string, bool)
+}
+
+// CtxV5 is WIT v0.5.0: v0.4.0 + the local timezone (RFC 0019).
+type CtxV5 interface {
+	CtxV4
+	// LocalTimezone is the machine's local IANA timezone name (e.g.
+	// "Europe/Berlin"), as the host sees it (RFC 0019). Best-effort: "UTC" if the
+	// host can't determine the zone. The WASI sandbox has no /etc/localtime or TZ;
+	// most plugins want [Local], which resolves this name to a *time.Location.
+	// Needs no capability grant (it reads nothing sensitive and runs nothing).
+	LocalTimezone() string
+}
+
+// CtxV6 is WIT v0.6.0: v0.5.0 + streaming HTTP (RFC 0020).
+type CtxV6 interface {
+	CtxV5
+	// HTTPOpen starts a streaming GET (RFC 0020) and returns the body as an
+	// io.ReadCloser, pulled from the host in bounded chunks. Same network grant as
+	// HTTPGet — it's the same request, just delivered so you can filter/reduce a
+	// large body (a multi-MB ICS or JSON feed) through bufio.Scanner or a decoder
+	// without ever holding it whole in the 2 MiB sandbox.
+	//
+	// Read returns io.EOF at end of stream (the host has already released it);
+	// Close abandons it early and is idempotent — defer it. Like HTTPGet, every
+	// Read parks the guest on I/O, so use it on the timer path.
+	HTTPOpen(url string) (io.ReadCloser, error)
+}
+
+// ExecOutput is the result of [CtxV3.Exec]: a finished program's exit code and
+// output.
+type ExecOutput struct {
+	Code   int // process exit code (-1 if it was killed by a signal)
+	Stdout []byte
+	Stderr []byte
+}
+
+// StdoutString is Stdout as a trimmed string — the common case for a CLI's output.
+func (o ExecOutput) StdoutString() string { return strings.TrimSpace(string(o.Stdout)) }
+
+// SwayWorkspace is one workspace, as read via [CtxV2.SwaySnapshot].
+type SwayWorkspace struct {
+	Name    string
+	Focused bool // the active workspace on the focused output
+	Visible bool // visible on *some* output (focused, or active on another monitor)
+	Urgent  bool // flagged urgent by a client
+}
+
+// SwayState is a read-only sway snapshot: the workspace list + the focused
+// window title.
+type SwayState struct {
+	Workspaces []SwayWorkspace
+	Title      string
+}
+
 // The host-sampled system feeds (aliases of the generated enum), for FeedSubscribe.
 // FeedPing is accepted but unsupported in v1 (no target in the ABI).
 const (
@@ -78,10 +235,11 @@ const (
 // Plugin is the thing you implement. Only View is required; embed [Base] for
 // no-op defaults of the rest, and override what you need.
 type Plugin interface {
-	// Load receives the [modules.<id>] config table (also re-delivered on a live
-	// config change).
+	// Load receives the [modules.<id>] config table on init. A live change to
+	// it arrives later as an EvConfig event, with what changed.
 	Load(config map[string]string)
 	// Update advances state on an event; return true if the chip must re-render.
+	// A pointer event on an [OnClick] area goes to its handler instead.
 	Update(ctx Ctx, ev Event) bool
 	// View builds the chip. PURE + synchronous: no host calls, no I/O — do that
 	// in Update. Called only when Update returned true.
@@ -106,49 +264,199 @@ func (Base) Popup() (Render, bool)  { return Render{}, false }
 func (Base) SaveState() []byte      { return nil }
 func (Base) Restore([]byte)         {}
 
+// Option tunes [Register].
+type Option func(*options)
+
+type options struct {
+	debug bool
+	trace *trace // set by Record
+}
+
+// registered is the Plugin passed to Register.
+var registered Plugin
+
+// Debug validates every View and Popup tree before it goes to the host, and
+// logs the path to any node the host would reject or cut short (see
+// [Render.Validate]). The tree is still sent as-is. It walks each tree twice,
+// so leave it out of release builds:
+//
+//	func init() { ezbar.Register(&My{}, ezbar.Debug()) }
+func Debug() Option { return func(o *options) { o.debug = true } }
+
+// check logs r's validation error under the export that built it, in debug mode.
+func (o *options) check(export string, r Render) Render {
+	if o.debug {
+		if err := r.Validate(); err != nil {
+			hostCtx{}.Log("ezbar: " + export + ": " + err.Error())
+		}
+	}
+	return r
+}
+
 // Register wires your plugin to the component exports. Call it once, from init:
 //
 //	func init() { ezbar.Register(&My{}) }
 //	func main()  {}
-func Register(p Plugin) {
-	plugin.Exports.Init = func(config cm.List[[2]string]) {
-		p.Load(pairsToMap(config))
+func Register(p Plugin, opts ...Option) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	registered = p
+	var config map[string]string // as of init or the last EvConfig, for the diff
+	var handlers Handlers        // the OnClick areas of the trees last handed over
+	pluginTimers = Timers{}
+	exportCtx = hostCtx{}
+	if o.trace != nil {
+		exportCtx = recorder{Ctx: hostCtx{}, t: o.trace}
+	}
+	plugin.Exports.Init = func(cfg cm.List[[2]string]) {
+		emPx = hostCtx{}.TextSize()
+		o.trace.config("init", emPx, cfg)
+		config = pairsToMap(cfg)
+		p.Load(pairsToMap(cfg))
 	}
 	plugin.Exports.Update = func(ev plugin.Event) bool {
-		// a config event re-delivers the [modules.<id>] table to Load.
+		e := fromWASMEvent(ev)
 		if cfg := ev.Config(); cfg != nil {
-			p.Load(pairsToMap(*cfg))
-			return true
+			emPx = hostCtx{}.TextSize()
+			o.trace.config("config", emPx, *cfg)
+			e.Diff = DiffConfig(config, e.Config)
+			config = pairsToMap(*cfg)
+		} else {
+			o.trace.event(e)
+		}
+		redraw, ok := handlers.Dispatch(e)
+		if !ok {
+			redraw = pluginTimers.Update(exportCtx, e, p.Update)
 		}
-		return p.Update(hostCtx{}, fromWASMEvent(ev))
+		o.trace.done(redraw)
+		return redraw
+	}
+	plugin.Exports.View = func() plugin.Tree {
+		o.trace.emit("view")
+		return lower(o.check("view", handlers.View(p.View())))
 	}
-	plugin.Exports.View = func() plugin.Tree { return lower(p.View()) }
 	plugin.Exports.Popup = func() cm.Option[plugin.Tree] {
+		o.trace.emit("popup")
 		if tree, ok := p.Popup(); ok {
-			return cm.Some(lower(tree))
+			return cm.Some(lower(o.check("popup", handlers.Popup(tree))))
 		}
 		return cm.None[plugin.Tree]()
 	}
 	plugin.Exports.SaveState = func() cm.List[uint8] { return cm.ToList(p.SaveState()) }
-	plugin.Exports.Restore = func(state cm.List[uint8]) { p.Restore(state.Slice()) }
+	plugin.Exports.Restore = func(state cm.List[uint8]) {
+		o.trace.restore(state.Slice())
+		p.Restore(state.Slice())
+	}
 }
 
-// hostCtx bridges Ctx onto the generated host imports.
+// hostCtx bridges Ctx onto the host imports of the targeted WIT version: its
+// methods live in host_v*.go, one file per version, each built under its tag
+// (and host_native.go outside wasm). The helpers below are the
+// version-independent halves of those bridges.
 type hostCtx struct{}
 
-func (hostCtx) Log(msg string)       { host.Log(msg) }
-func (hostCtx) SetTimeout(ms uint32) { host.SetTimeout(ms) }
-func (hostCtx) FeedSubscribe(feed FeedKind, minPeriodMs uint32) {
-	host.FeedSubscribe(feed, minPeriodMs)
-}
-func (hostCtx) HTTPGet(url string) ([]byte, error) {
-	res := host.HTTPGet(url)
+// unwrap turns a host result<T, string> into (T, error).
+func unwrap[Shape, T any](res cm.Result[Shape, T, string]) (T, error) {
 	if res.IsErr() {
-		return nil, errors.New(*res.Err())
+		var zero T
+		return zero, errors.New(*res.Err())
+	}
+	return *res.OK(), nil
+}
+
+func bytesOf(l cm.List[uint8], err error) ([]byte, error) { return l.Slice(), err }
+
+// deniedPrefix starts every host error for an ungranted capability.
+const deniedPrefix = "capability denied"
+
+// readFile turns a read-file result into fs-style errors: a denial wraps
+// fs.ErrPermission, anything else carries the host's message.
+func readFile(path string, res cm.Result[cm.List[uint8], cm.List[uint8], string]) ([]byte, error) {
+	if msg := res.Err(); msg != nil {
+		err := errors.New(*msg)
+		if strings.HasPrefix(*msg, deniedPrefix) {
+			err = fs.ErrPermission
+		}
+		return nil, &fs.PathError{Op: "read", Path: path, Err: err}
 	}
 	return res.OK().Slice(), nil
 }
 
+func subscribeKinds(kinds []EventKind) cm.List[types.EventKind] {
+	l := make([]types.EventKind, len(kinds))
+	for i, k := range kinds {
+		l[i] = types.EventKind(k)
+	}
+	return cm.ToList(l)
+}
+
+func execArgs(args []string, stdin []byte) (cm.List[string], cm.Option[cm.List[uint8]]) {
+	if stdin == nil {
+		return cm.ToList(args), cm.None[cm.List[uint8]]()
+	}
+	return cm.ToList(args), cm.Some(cm.ToList(stdin))
+}
+
+func execOutput(code int32, stdout, stderr cm.List[uint8]) ExecOutput {
+	return ExecOutput{Code: int(code), Stdout: stdout.Slice(), Stderr: stderr.Slice()}
+}
+
+func pickArgs(items []string, current int) (cm.List[string], cm.Option[uint32]) {
+	if current < 0 || current >= len(items) {
+		return cm.ToList(items), cm.None[uint32]()
+	}
+	return cm.ToList(items), cm.Some(uint32(current))
+}
+
+func picked(res cm.Option[string]) (string, bool) {
+	if v := res.Some(); v != nil {
+		return *v, true
+	}
+	return "", false
+}
+
+// httpChunkMax bounds one http-read, so a big Read buffer can't make the host
+// hand back more than the sandbox comfortably holds at once.
+const httpChunkMax = 64 << 10
+
+// httpStream is the io.ReadCloser over an http-open handle; read/close are the
+// targeted version's http-read/http-close.
+type httpStream struct {
+	handle uint64
+	read   func(handle uint64, max uint32) cm.Result[cm.List[uint8], cm.List[uint8], string]
+	close  func(handle uint64)
+	done   bool // EOF (host already dropped it), an error, or Close
+}
+
+func (s *httpStream) Read(p []byte) (int, error) {
+	if s.done {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	chunk, err := bytesOf(unwrap(s.read(s.handle, uint32(min(len(p), httpChunkMax)))))
+	if err != nil {
+		s.Close()
+		return 0, err
+	}
+	if len(chunk) == 0 {
+		s.done = true
+		return 0, io.EOF
+	}
+	return copy(p, chunk), nil
+}
+
+func (s *httpStream) Close() error {
+	if !s.done {
+		s.done = true
+		s.close(s.handle)
+	}
+	return nil
+}
+
 func pairsToMap(l cm.List[[2]string]) map[string]string {
 	m := make(map[string]string, l.Len())
 	for _, kv := range l.Slice() {
@@ -159,13 +467,15 @@ func pairsToMap(l cm.List[[2]string]) map[string]string {
 
 // ── events ──────────────────────────────────────────────────────────────────
 
-// EventKind tags an [Event].
+// EventKind tags an [Event]. The values are kept in lock-step with the WIT
+// event-kind enum (same order), which is what [CtxV1.Subscribe] sends.
 type EventKind uint8
 
 const (
 	EvTimer   EventKind = iota // a timer tick (drive your polling here)
 	EvPointer                  // a pointer event on a mouse-area you declared
 	EvFeed                     // a host data-feed sample you subscribed to
+	EvConfig                   // a live change to the [modules.<id>] config table
 )
 
 // PointerKind is which pointer interaction fired (for EvPointer).
@@ -187,6 +497,9 @@ type FeedKind = types.FeedKind
 type Event struct {
 	Kind EventKind
 
+	// EvTimer:
+	Timer string // the Ctx.After/Every timer that fired; "" for SetTimeout's or the host's own
+
 	// EvPointer:
 	PointerID   string      // the mouse-area id you set
 	PointerKind PointerKind // Press / RightPress / Scroll / Enter / Leave
@@ -195,6 +508,10 @@ type Event struct {
 	// EvFeed:
 	Feed  FeedKind
 	Value float64
+
+	// EvConfig:
+	Config map[string]string // the whole new table, as Load got it on init
+	Diff   ConfigDiff        // its keys that differ from the table before
 }
 
 func fromWASMEvent(ev plugin.Event) Event {
@@ -204,5 +521,8 @@ func fromWASMEvent(ev plugin.Event) Event {
 	if f := ev.Feed(); f != nil {
 		return Event{Kind: EvFeed, Feed: f.Feed, Value: f.Value}
 	}
+	if c := ev.Config(); c != nil {
+		return Event{Kind: EvConfig, Config: pairsToMap(*c)}
+	}
 	return Event{Kind: EvTimer}
 }
@@ -0,0 +1,353 @@
+//go:build !wasm
+
+// Package ezbartest unit-tests an ezbar [ezbar.Plugin] under plain `go test`:
+// a scriptable fake [Ctx] that records what the plugin asked of the host, and a
+// [Driver] that feeds the plugin the same Load/Update/View/Popup sequence the
+// host would.
+//
+//	func TestClock(t *testing.T) {
+//		d := ezbartest.New(t, &Clock{})
+//		if !d.Tick() {
+//			t.Fatal("a tick should re-render")
+//		}
+//		if ms, _ := d.Ctx.Timeout(); ms != 10_000 {
+//			t.Fatalf("re-armed for %dms, want 10s", ms)
+//		}
+//		d.View() // fails the test if the host would reject the tree
+//	}
+//
+// It builds natively only (not for wasm): a Driver also installs its Ctx as the
+// in-process host via [ezbar.UseHost], so SDK calls that reach the host without
+// a Ctx in hand, such as [ezbar.Local], hit the fake too; and its Load and
+// Configure refresh [ezbar.Em] from the fake's TextPx, as the bar's init and
+// EvConfig do. That host, like the clock and Em, is process-wide: a test that
+// makes a Driver, a [Sim] or a [Replay] must not call t.Parallel.
+package ezbartest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"maps"
+	"time"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+)
+
+// Ctx is a fake host. The zero value is ready to use: it grants nothing, like a
+// host with an empty [modules.<id>] table. Script it by setting the fields (or
+// [Ctx.Respond]/[Ctx.Fail]) before driving the plugin, and read back what the
+// plugin called from the recorded fields afterwards.
+//
+// *Ctx implements [ezbar.CtxV6], so it satisfies ezbar.Ctx under any
+// ezbar_wit_v* tag.
+type Ctx struct {
+	// Recorded calls, in order.
+	Logs          []string            // every Log message
+	Timeouts      []uint32            // every SetTimeout, including 0 (cancel)
+	Named         []TimerCall         // every After, Every and Stop
+	Feeds         []FeedSub           // every FeedSubscribe
+	Subscriptions [][]ezbar.EventKind // every Subscribe
+	Requests      []string            // every URL passed to HTTPGet or HTTPOpen
+
+	// HTTP serves HTTPGet/HTTPOpen by exact URL; a URL with no entry goes to
+	// HTTPFunc, or is denied, as for a host the user didn't grant.
+	HTTP map[string]Response
+	// HTTPFunc serves the URLs HTTP has no entry for, as a stream — a
+	// [Fixtures] router's Open, say. A Read error partway through is a
+	// download cut short: HTTPGet fails with it, HTTPOpen's reader returns it.
+	HTTPFunc func(url string) (io.ReadCloser, error)
+	// Files serves ReadFile by path; a path with no entry is denied.
+	Files map[string][]byte
+	// Sway is what SwaySnapshot returns; nil denies it.
+	Sway *ezbar.SwayState
+	// ExecFunc runs Exec; nil denies every program.
+	ExecFunc func(program string, args []string, stdin []byte) (ezbar.ExecOutput, error)
+	// PickFunc answers Pick; nil dismisses every picker.
+	PickFunc func(prompt string, items []string, current int) (string, bool)
+
+	TextPx   float32     // TextSize; 0 means the host default, 14
+	FgColor  ezbar.Color // Fg; the zero Color is the Fg token
+	Timezone string      // LocalTimezone; "" means "UTC"
+}
+
+var _ ezbar.CtxV6 = (*Ctx)(nil)
+
+// FeedSub is one recorded FeedSubscribe call.
+type FeedSub struct {
+	Feed        ezbar.FeedKind
+	MinPeriodMs uint32
+}
+
+// TimerCall is one recorded After, Every or Stop call. A [Driver] keeps the
+// named timers itself, so these are only the calls made on a Ctx handed to
+// the plugin directly.
+type TimerCall struct {
+	Op   string // "After", "Every" or "Stop"
+	Name string
+	D    time.Duration // 0 for Stop
+}
+
+// Response is a canned HTTP response: Body, or Err if it's set.
+type Response struct {
+	Body []byte
+	Err  error
+}
+
+// Respond serves body for url.
+func (c *Ctx) Respond(url string, body []byte) {
+	if c.HTTP == nil {
+		c.HTTP = map[string]Response{}
+	}
+	c.HTTP[url] = Response{Body: body}
+}
+
+// Fail makes a request for url error with err.
+func (c *Ctx) Fail(url string, err error) {
+	if c.HTTP == nil {
+		c.HTTP = map[string]Response{}
+	}
+	c.HTTP[url] = Response{Err: err}
+}
+
+// Timeout is the last SetTimeout (0 if it cancelled); ok is false if the plugin
+// never armed one.
+func (c *Ctx) Timeout() (ms uint32, ok bool) {
+	if len(c.Timeouts) == 0 {
+		return 0, false
+	}
+	return c.Timeouts[len(c.Timeouts)-1], true
+}
+
+func (c *Ctx) Log(msg string)       { c.Logs = append(c.Logs, msg) }
+func (c *Ctx) SetTimeout(ms uint32) { c.Timeouts = append(c.Timeouts, ms) }
+
+func (c *Ctx) After(name string, d time.Duration) {
+	c.Named = append(c.Named, TimerCall{Op: "After", Name: name, D: d})
+}
+
+func (c *Ctx) Every(name string, d time.Duration) {
+	c.Named = append(c.Named, TimerCall{Op: "Every", Name: name, D: d})
+}
+
+func (c *Ctx) Stop(name string) { c.Named = append(c.Named, TimerCall{Op: "Stop", Name: name}) }
+
+func (c *Ctx) Subscribe(kinds ...ezbar.EventKind) {
+	c.Subscriptions = append(c.Subscriptions, append([]ezbar.EventKind(nil), kinds...))
+}
+
+func (c *Ctx) FeedSubscribe(feed ezbar.FeedKind, minPeriodMs uint32) {
+	c.Feeds = append(c.Feeds, FeedSub{Feed: feed, MinPeriodMs: minPeriodMs})
+}
+
+func (c *Ctx) TextSize() float32 {
+	if c.TextPx == 0 {
+		return 14
+	}
+	return c.TextPx
+}
+
+func (c *Ctx) Fg() ezbar.Color { return c.FgColor }
+
+func (c *Ctx) HTTPGet(url string) ([]byte, error) {
+	if _, ok := c.HTTP[url]; !ok && c.HTTPFunc != nil {
+		body, err := c.HTTPOpen(url)
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+	c.Requests = append(c.Requests, url)
+	r, ok := c.HTTP[url]
+	if !ok {
+		return nil, errors.New("capability denied: no canned response for " + url)
+	}
+	return r.Body, r.Err
+}
+
+func (c *Ctx) HTTPOpen(url string) (io.ReadCloser, error) {
+	if _, ok := c.HTTP[url]; !ok && c.HTTPFunc != nil {
+		c.Requests = append(c.Requests, url)
+		return c.HTTPFunc(url)
+	}
+	body, err := c.HTTPGet(url)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(body)), nil
+}
+
+func (c *Ctx) ReadFile(path string) ([]byte, error) {
+	data, ok := c.Files[path]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: path, Err: fs.ErrPermission}
+	}
+	return data, nil
+}
+
+func (c *Ctx) SwaySnapshot() (ezbar.SwayState, error) {
+	if c.Sway == nil {
+		return ezbar.SwayState{}, errors.New("capability denied: sway not granted")
+	}
+	return *c.Sway, nil
+}
+
+func (c *Ctx) Exec(program string, args []string, stdin []byte) (ezbar.ExecOutput, error) {
+	if c.ExecFunc == nil {
+		return ezbar.ExecOutput{}, errors.New("capability denied: exec '" + program + "' not granted")
+	}
+	return c.ExecFunc(program, args, stdin)
+}
+
+func (c *Ctx) Pick(prompt string, items []string, current int) (string, bool) {
+	if c.PickFunc == nil {
+		return "", false
+	}
+	return c.PickFunc(prompt, items, current)
+}
+
+func (c *Ctx) LocalTimezone() string {
+	if c.Timezone == "" {
+		return "UTC"
+	}
+	return c.Timezone
+}
+
+// TB is the part of testing.TB a [Driver] and a [Sim] report through, so a
+// tool can run them outside a test, as ezbar/preview does; a *testing.T is
+// one.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Logf(format string, args ...any)
+	Cleanup(func())
+}
+
+// Driver runs a Plugin against a fake [Ctx], the way the host does. Every tree
+// it hands back has passed [ezbar.Render.Validate], or the test fails.
+type Driver struct {
+	t   TB
+	P   ezbar.Plugin
+	Ctx *Ctx
+
+	host     ezbar.Ctx         // what Update hands the plugin: Ctx, or a replay over it
+	config   map[string]string // the table as of Load or the last Configure
+	handlers ezbar.Handlers    // the OnClick areas of the last View and Popup
+	timers   ezbar.Timers      // the plugin's After/Every timers
+	now      time.Duration     // the virtual clock, moved on by Tick
+	ticked   int               // len(Ctx.Timeouts) as of the last Tick
+	textSize func() float32    // what Load and Configure refresh Em from
+}
+
+// epoch is where a virtual clock starts.
+var epoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// New drives p with an empty [Ctx]; script d.Ctx before the first event. The
+// Ctx is the native host, and the Driver's virtual clock the SDK's (see
+// [ezbar.UseClock]), until the test ends.
+func New(t TB, p ezbar.Plugin) *Driver {
+	d := &Driver{t: t, P: p, Ctx: &Ctx{}}
+	d.host, d.textSize = d.Ctx, d.Ctx.TextSize
+	ezbar.UseHost(d.Ctx)
+	ezbar.UseClock(func() time.Time { return epoch.Add(d.now) })
+	t.Cleanup(func() { ezbar.UseHost(nil); ezbar.UseClock(nil); ezbar.SetEm(0) })
+	return d
+}
+
+// Load delivers the [modules.<id>] config table, as on init.
+func (d *Driver) Load(config map[string]string) {
+	ezbar.SetEm(d.textSize())
+	d.config = maps.Clone(config)
+	d.P.Load(config)
+}
+
+// Configure delivers a live change to the config table: an EvConfig with
+// config and how it differs from the table before.
+func (d *Driver) Configure(config map[string]string) bool {
+	return d.Update(d.configEvent(config))
+}
+
+func (d *Driver) configEvent(config map[string]string) ezbar.Event {
+	ezbar.SetEm(d.textSize())
+	ev := ezbar.Event{Kind: ezbar.EvConfig, Config: config, Diff: ezbar.DiffConfig(d.config, config)}
+	d.config = maps.Clone(config)
+	return ev
+}
+
+// Update delivers ev and reports whether the plugin asked to re-render. A
+// pointer event on an [ezbar.OnClick] area goes to its handler instead, as on
+// the bar; the area's id is the one in the last View or Popup.
+func (d *Driver) Update(ev ezbar.Event) bool {
+	if redraw, ok := d.handlers.Dispatch(ev); ok {
+		return redraw
+	}
+	return d.timers.Update(d.host, ev, d.P.Update)
+}
+
+// Tick delivers an EvTimer. If the plugin armed a timer since the last Tick,
+// the virtual clock first moves on to when it fires. If its last SetTimeout
+// was a cancel, there's no timer to fire: Tick fails the test and returns
+// false, delivering nothing, as the host would.
+func (d *Driver) Tick() bool {
+	if ms, ok := d.Ctx.Timeout(); ok && ms == 0 {
+		d.t.Helper()
+		d.t.Errorf("Tick: the plugin cancelled its timer (SetTimeout(0)); the host would deliver no EvTimer")
+		return false
+	}
+	if ts := d.Ctx.Timeouts[d.ticked:]; len(ts) > 0 && ts[len(ts)-1] > 0 {
+		d.now += max(time.Duration(ts[len(ts)-1])*time.Millisecond, MinTimer)
+	}
+	d.ticked = len(d.Ctx.Timeouts)
+	return d.Update(ezbar.Event{Kind: ezbar.EvTimer})
+}
+
+// Pointer delivers an EvPointer on the mouse-area id.
+func (d *Driver) Pointer(id string, kind ezbar.PointerKind, delta float32) bool {
+	return d.Update(ezbar.Event{Kind: ezbar.EvPointer, PointerID: id, PointerKind: kind, Delta: delta})
+}
+
+// Click delivers a Press on the mouse-area id.
+func (d *Driver) Click(id string) bool { return d.Pointer(id, ezbar.Press, 0) }
+
+// Feed delivers an EvFeed sample.
+func (d *Driver) Feed(feed ezbar.FeedKind, value float64) bool {
+	return d.Update(ezbar.Event{Kind: ezbar.EvFeed, Feed: feed, Value: value})
+}
+
+// View builds the chip.
+func (d *Driver) View() ezbar.Render {
+	d.t.Helper()
+	return d.valid("View", d.handlers.View(d.P.View()))
+}
+
+// Popup builds the hover popup; ok is false if the plugin has none.
+func (d *Driver) Popup() (tree ezbar.Render, ok bool) {
+	d.t.Helper()
+	tree, ok = d.P.Popup()
+	if ok {
+		tree = d.valid("Popup", d.handlers.Popup(tree))
+	}
+	return tree, ok
+}
+
+// Reload hands state across a clean reload, SaveState to Restore, as the host
+// does when the plugin is rebuilt; next is the fresh instance, which Driver
+// drives from then on.
+func (d *Driver) Reload(next ezbar.Plugin) {
+	next.Restore(d.P.SaveState())
+	d.P = next
+}
+
+func (d *Driver) valid(export string, r ezbar.Render) ezbar.Render {
+	d.t.Helper()
+	if err := r.Validate(); err != nil {
+		d.t.Errorf("%s: %v", export, err)
+	}
+	return r
+}
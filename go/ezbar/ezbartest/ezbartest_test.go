@@ -0,0 +1,253 @@
+package ezbartest_test
+
+import (
+	"errors"
+	"io/fs"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+	"github.com/birdayz/ezbar/go/ezbar/ezbartest"
+)
+
+// weather is a small plugin in the shape of the real ones: config in Load, a
+// fetch on each tick, a click toggle and a popup.
+type weather struct {
+	ezbar.Base
+	city    string
+	temp    string
+	err     bool
+	compact bool
+}
+
+func (w *weather) Load(config map[string]string) { w.city = config["city"] }
+
+func (w *weather) Update(ctx ezbar.Ctx, ev ezbar.Event) bool {
+	switch ev.Kind {
+	case ezbar.EvTimer:
+		ctx.SetTimeout(60_000)
+		body, err := ctx.HTTPGet("https://wttr.in/" + w.city)
+		if err != nil {
+			ctx.Log("fetch: " + err.Error())
+			w.err = true
+			return true
+		}
+		w.temp, w.err = strings.TrimSpace(string(body)), false
+		return true
+	case ezbar.EvConfig:
+		w.Load(ev.Config)
+		if ev.Diff.Touches("city") {
+			return w.Update(ctx, ezbar.Event{Kind: ezbar.EvTimer})
+		}
+	case ezbar.EvPointer:
+		if ev.PointerID == "chip" && ev.PointerKind == ezbar.Press {
+			w.compact = !w.compact
+			return true
+		}
+	}
+	return false
+}
+
+func (w *weather) View() ezbar.Render {
+	if w.err {
+		return ezbar.IconAlert.View(14, ezbar.Warn)
+	}
+	return ezbar.MouseArea("chip", ezbar.Text(w.temp))
+}
+
+func (w *weather) Popup() (ezbar.Render, bool) {
+	return ezbar.Text(w.city), w.city != ""
+}
+
+func TestDriver(t *testing.T) {
+	d := ezbartest.New(t, &weather{})
+	d.Ctx.Respond("https://wttr.in/Berlin", []byte("12°C\n"))
+	d.Load(map[string]string{"city": "Berlin"})
+
+	if !d.Tick() {
+		t.Fatal("Tick: want a re-render")
+	}
+	if ms, ok := d.Ctx.Timeout(); !ok || ms != 60_000 {
+		t.Errorf("Timeout() = %d, %v; want 60000, true", ms, ok)
+	}
+	if got, want := d.Ctx.Requests, []string{"https://wttr.in/Berlin"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("Requests = %q, want %q", got, want)
+	}
+	if got := d.P.(*weather).temp; got != "12°C" {
+		t.Errorf("temp = %q, want 12°C", got)
+	}
+	if !d.Click("chip") || !d.P.(*weather).compact {
+		t.Error("Click(chip) should toggle compact")
+	}
+	if d.Click("other") {
+		t.Error("Click(other) should not re-render")
+	}
+	d.View()
+	if _, ok := d.Popup(); !ok {
+		t.Error("Popup: want one once a city is set")
+	}
+}
+
+func TestDriverConfigure(t *testing.T) {
+	d := ezbartest.New(t, &weather{})
+	d.Ctx.Respond("https://wttr.in/Berlin", []byte("12°C"))
+	d.Ctx.Respond("https://wttr.in/Oslo", []byte("3°C"))
+	d.Load(map[string]string{"city": "Berlin", "theme": "dark"})
+
+	if d.Configure(map[string]string{"city": "Berlin", "theme": "light"}) {
+		t.Error("a theme change should not re-fetch or re-render")
+	}
+	if !d.Configure(map[string]string{"city": "Oslo"}) {
+		t.Error("a city change should re-render")
+	}
+	if got := d.Ctx.Requests; len(got) != 1 || got[0] != "https://wttr.in/Oslo" {
+		t.Errorf("Requests = %q, want just the new city", got)
+	}
+	if got := d.P.(*weather).temp; got != "3°C" {
+		t.Errorf("temp = %q, want 3°C", got)
+	}
+}
+
+func TestDriverEm(t *testing.T) {
+	d := ezbartest.New(t, &weather{})
+	d.Ctx.TextPx = 20
+	d.Load(map[string]string{"city": "Berlin"})
+	if got := ezbar.Em(1); got != 20 {
+		t.Errorf("Em(1) after Load = %v, want the fake's 20", got)
+	}
+	d.Ctx.TextPx = 16
+	d.Configure(map[string]string{"city": "Berlin"})
+	if got := ezbar.Em(1); got != 16 {
+		t.Errorf("Em(1) after Configure = %v, want the fake's 16", got)
+	}
+}
+
+func TestDriverDenied(t *testing.T) {
+	d := ezbartest.New(t, &weather{city: "Oslo"})
+	d.Tick()
+	if !d.P.(*weather).err {
+		t.Error("an uncanned URL should be denied")
+	}
+	if len(d.Ctx.Logs) != 1 || !strings.HasPrefix(d.Ctx.Logs[0], "fetch: capability denied") {
+		t.Errorf("Logs = %q", d.Ctx.Logs)
+	}
+
+	boom := errors.New("boom")
+	d.Ctx.Fail("https://wttr.in/Oslo", boom)
+	d.Tick()
+	if len(d.Ctx.Logs) != 2 || d.Ctx.Logs[1] != "fetch: boom" {
+		t.Errorf("Logs = %q", d.Ctx.Logs)
+	}
+}
+
+func TestCtxDefaults(t *testing.T) {
+	var c ezbartest.Ctx
+	if got := c.TextSize(); got != 14 {
+		t.Errorf("TextSize() = %v, want 14", got)
+	}
+	if got := c.Fg(); got != ezbar.Fg {
+		t.Errorf("Fg() = %v, want the Fg token", got)
+	}
+	if got := c.LocalTimezone(); got != "UTC" {
+		t.Errorf("LocalTimezone() = %q, want UTC", got)
+	}
+	if _, err := c.ReadFile("/etc/hostname"); !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("ReadFile: err = %v, want fs.ErrPermission", err)
+	}
+	if _, err := c.SwaySnapshot(); err == nil {
+		t.Error("SwaySnapshot: want a denial")
+	}
+	if _, err := c.Exec("true", nil, nil); err == nil {
+		t.Error("Exec: want a denial")
+	}
+	if _, ok := c.Pick("pick", []string{"a"}, 0); ok {
+		t.Error("Pick: want a dismissal")
+	}
+	c.FeedSubscribe(ezbar.FeedCPU, 1000)
+	c.Subscribe(ezbar.EvPointer, ezbar.EvFeed)
+	if len(c.Feeds) != 1 || c.Feeds[0] != (ezbartest.FeedSub{Feed: ezbar.FeedCPU, MinPeriodMs: 1000}) {
+		t.Errorf("Feeds = %v", c.Feeds)
+	}
+	if len(c.Subscriptions) != 1 || len(c.Subscriptions[0]) != 2 {
+		t.Errorf("Subscriptions = %v", c.Subscriptions)
+	}
+	c.Every("tick", time.Second)
+	c.Stop("tick")
+	if want := []ezbartest.TimerCall{{Op: "Every", Name: "tick", D: time.Second}, {Op: "Stop", Name: "tick"}}; !slices.Equal(c.Named, want) {
+		t.Errorf("Named = %v, want %v", c.Named, want)
+	}
+}
+
+func TestViewValidates(t *testing.T) {
+	ft := fakeT{TB: t}
+	ezbartest.New(&ft, &weather{temp: "ok"}).View()
+	if ft.failed {
+		t.Fatal("a valid tree failed the test")
+	}
+	ezbartest.New(&ft, deep{}).View()
+	if !ft.failed {
+		t.Error("a tree past MaxDepth should fail the test")
+	}
+}
+
+// quiet cancels its timer on the bootstrap tick, like a purely reactive chip.
+type quiet struct {
+	ezbar.Base
+	ticks int
+}
+
+func (q *quiet) Update(ctx ezbar.Ctx, ev ezbar.Event) bool {
+	if ev.Kind == ezbar.EvTimer {
+		q.ticks++
+		ctx.SetTimeout(0)
+	}
+	return false
+}
+
+func (*quiet) View() ezbar.Render { return ezbar.Text("") }
+
+func TestTickCancelled(t *testing.T) {
+	ft := fakeT{TB: t}
+	q := &quiet{}
+	d := ezbartest.New(&ft, q)
+	d.Tick()
+	if ft.failed {
+		t.Fatal("the bootstrap tick failed the test")
+	}
+	if d.Tick() || !ft.failed || q.ticks != 1 {
+		t.Errorf("Tick after SetTimeout(0): failed = %v, ticks = %d; want a failure and no EvTimer", ft.failed, q.ticks)
+	}
+}
+
+type deep struct{ ezbar.Base }
+
+func (deep) View() ezbar.Render {
+	r := ezbar.Text("x")
+	for range ezbar.MaxDepth + 1 {
+		r = ezbar.Container(r)
+	}
+	return r
+}
+
+// fakeT records a failure instead of failing the test it wraps.
+type fakeT struct {
+	testing.TB
+	failed bool
+}
+
+func (f *fakeT) Helper()               {}
+func (f *fakeT) Errorf(string, ...any) { f.failed = true }
+
+func TestGolden(t *testing.T) {
+	d := ezbartest.New(t, &weather{})
+	d.Ctx.Respond("https://wttr.in/Berlin", []byte("12°C"))
+	d.Load(map[string]string{"city": "Berlin"})
+	d.Tick()
+	ezbartest.AssertGolden(t, "weather_chip", d.View())
+
+	d.Ctx.Fail("https://wttr.in/Berlin", errors.New("timeout"))
+	d.Tick()
+	ezbartest.AssertGolden(t, "weather_chip_error", d.View())
+}
@@ -0,0 +1,264 @@
+//go:build !wasm
+
+package ezbartest
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var recordFixtures = flag.Bool("record-fixtures", false, "fetch ezbartest HTTP fixtures that aren't on disk yet from the live URL and save them")
+
+// HTTPTimeout is how long the host waits on a request before failing it.
+const HTTPTimeout = 30 * time.Second
+
+// Fixture is one canned response. The zero value is an empty 200.
+type Fixture struct {
+	File   string        // the body, from this file under Fixtures.Dir
+	Body   []byte        // the body, if File is ""
+	Status int           // the HTTP status; 0 is 200, and anything outside 2xx fails the request
+	Delay  time.Duration // how long the response takes, on Waited only; at HTTPTimeout or beyond it times out
+	// Truncate, if > 0, drops the connection after that many bytes of the
+	// body: HTTPGet fails, and HTTPOpen's reader returns io.ErrUnexpectedEOF
+	// after them.
+	Truncate int
+}
+
+// Fixtures serves a plugin's HTTP requests from files by URL pattern, with
+// the host's allow-list in front — the test double for the pollers (weather,
+// quakes, prices) most plugins are:
+//
+//	fx := ezbartest.NewFixtures(t, "wttr.in")
+//	fx.Handle("https://wttr.in/Nowhere*", ezbartest.Fixture{Status: 404})
+//	fx.Handle("https://wttr.in/*", ezbartest.Fixture{File: "wttr/berlin.txt"})
+//	d.Ctx.HTTPFunc = fx.Open
+//
+// A request to a host outside Network is denied with the host's error. One
+// that's granted goes to the first route whose pattern matches, in the order
+// they were added; a pattern is a whole URL in which * stands for any run of
+// characters but /, and ** for any run at all. A granted URL that no route
+// matches is served from the one recorded for it under Dir/http, if any (see
+// Record); anything else fails the test.
+//
+// Delays are virtual: nothing sleeps. A delay of [HTTPTimeout] or more fails
+// the request as the host's timeout would, and every delay served adds to
+// Waited — and that's all a delay does. It doesn't move a Driver's or a
+// Sim's virtual clock, so the plugin's timers don't see the request take any
+// time; move the clock yourself (Sim.Run) to test staleness.
+type Fixtures struct {
+	Dir     string   // where File and recorded responses live; "testdata" by default
+	Network []string // the [modules.<id>].network grants: host, host:port or "*"
+	// Record fetches a granted URL that no route matches and that has no
+	// recording yet from the live network, and saves it under Dir/http, where
+	// later runs find it offline. It defaults to the -record-fixtures flag.
+	// A recording is never refreshed: delete the file to fetch it again.
+	Record bool
+	Waited time.Duration // the delays served so far
+
+	t      testing.TB
+	mu     sync.Mutex
+	routes []route
+}
+
+type route struct {
+	pattern string
+	fx      Fixture
+}
+
+// NewFixtures is a router with no routes, granting network.
+func NewFixtures(t testing.TB, network ...string) *Fixtures {
+	return &Fixtures{Dir: "testdata", Network: network, Record: *recordFixtures, t: t}
+}
+
+// Handle routes the URLs matching pattern to fx.
+func (f *Fixtures) Handle(pattern string, fx Fixture) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.routes = append(f.routes, route{pattern, fx})
+}
+
+// Open serves url the way the host would, for [Ctx.HTTPFunc].
+func (f *Fixtures) Open(url string) (io.ReadCloser, error) {
+	f.t.Helper()
+	host := urlHost(url)
+	granted := false
+	for _, g := range f.Network {
+		granted = granted || hostMatches(g, host)
+	}
+	if !granted {
+		return nil, fmt.Errorf("capability denied: network host '%s' not granted", host)
+	}
+	f.mu.Lock()
+	fx, ok := f.route(url)
+	f.mu.Unlock()
+	if !ok {
+		var err error
+		if fx, err = f.recorded(url); err != nil {
+			f.t.Errorf("ezbartest: %s: %v", url, err)
+			return nil, err
+		}
+	}
+	return f.serve(fx)
+}
+
+func (f *Fixtures) route(url string) (Fixture, bool) {
+	for _, r := range f.routes {
+		if globMatch(r.pattern, url) {
+			return r.fx, true
+		}
+	}
+	return Fixture{}, false
+}
+
+func (f *Fixtures) serve(fx Fixture) (io.ReadCloser, error) {
+	f.mu.Lock()
+	f.Waited += fx.Delay
+	f.mu.Unlock()
+	if fx.Delay >= HTTPTimeout {
+		return nil, errors.New("operation timed out")
+	}
+	if fx.Status != 0 && (fx.Status < 200 || fx.Status > 299) {
+		return nil, fmt.Errorf("http %d %s", fx.Status, http.StatusText(fx.Status))
+	}
+	body := fx.Body
+	if fx.File != "" {
+		var err error
+		if body, err = os.ReadFile(filepath.Join(f.Dir, fx.File)); err != nil {
+			f.t.Errorf("ezbartest: fixture: %v", err)
+			return nil, err
+		}
+	}
+	if fx.Truncate > 0 && fx.Truncate < len(body) {
+		return io.NopCloser(io.MultiReader(bytes.NewReader(body[:fx.Truncate]), errReader{io.ErrUnexpectedEOF})), nil
+	}
+	return io.NopCloser(bytes.NewReader(body)), nil
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+// recorded is the response recorded for url, fetched now in record mode.
+func (f *Fixtures) recorded(url string) (Fixture, error) {
+	name := filepath.Join("http", fixtureName(url))
+	path := filepath.Join(f.Dir, name)
+	if _, err := os.Stat(path); err == nil {
+		return Fixture{File: name}, nil
+	}
+	if !f.Record {
+		return Fixture{}, errors.New("no fixture routed or recorded (Handle it, or run with -record-fixtures to record it)")
+	}
+	body, err := fetchLive(url)
+	if err != nil {
+		return Fixture{}, fmt.Errorf("recording: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return Fixture{}, err
+	}
+	if err := os.WriteFile(path, body, 0o644); err != nil {
+		return Fixture{}, err
+	}
+	f.t.Logf("ezbartest: recorded %s to %s", url, path)
+	return Fixture{File: name}, nil
+}
+
+func fetchLive(url string) ([]byte, error) {
+	c := &http.Client{Timeout: HTTPTimeout}
+	resp, err := c.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("http %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// fixtureName is url as a file name: readable, but with a hash of the whole
+// URL so two that flatten alike stay apart.
+func fixtureName(url string) string {
+	_, rest, ok := strings.Cut(url, "://")
+	if !ok {
+		rest = url
+	}
+	name := []byte(rest)
+	for i, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '-') {
+			name[i] = '_'
+		}
+	}
+	if len(name) > 80 {
+		name = name[:80]
+	}
+	h := fnv.New32a()
+	h.Write([]byte(url))
+	return fmt.Sprintf("%s-%08x", name, h.Sum32())
+}
+
+// urlHost is the host[:port] authority of url, after the scheme and before
+// the path, as the host lifts it for the allow-list.
+func urlHost(url string) string {
+	if _, rest, ok := strings.Cut(url, "://"); ok {
+		url = rest
+	}
+	if i := strings.IndexAny(url, "/?#"); i >= 0 {
+		url = url[:i]
+	}
+	return url
+}
+
+// hostMatches is the host's host_matches: does a network grant cover
+// urlHost? Case-insensitive; "*" grants any host; a grant with a port must
+// match host:port exactly, one without covers the host on any port.
+func hostMatches(grant, urlHost string) bool {
+	g := strings.ToLower(strings.TrimSpace(grant))
+	h := strings.ToLower(strings.TrimSpace(urlHost))
+	switch {
+	case g == "":
+		return false
+	case g == "*":
+		return true
+	case strings.Contains(g, ":"):
+		return g == h
+	}
+	host, _, _ := strings.Cut(h, ":")
+	return host == g
+}
+
+// globMatch matches s against a pattern where * is any run without a / and
+// ** any run at all.
+func globMatch(pattern, s string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] != '*' {
+			if len(s) == 0 || s[0] != pattern[0] {
+				return false
+			}
+			pattern, s = pattern[1:], s[1:]
+			continue
+		}
+		deep := strings.HasPrefix(pattern, "**")
+		pattern = strings.TrimLeft(pattern, "*")
+		for i := 0; i <= len(s); i++ {
+			if globMatch(pattern, s[i:]) {
+				return true
+			}
+			if i < len(s) && s[i] == '/' && !deep {
+				return false
+			}
+		}
+		return false
+	}
+	return len(s) == 0
+}
@@ -0,0 +1,123 @@
+package ezbartest_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/birdayz/ezbar/go/ezbar/ezbartest"
+)
+
+func TestFixtures(t *testing.T) {
+	fx := ezbartest.NewFixtures(t, "wttr.in", "API.example.com:8443")
+	fx.Handle("https://wttr.in/Nowhere*", ezbartest.Fixture{Status: 404})
+	fx.Handle("https://wttr.in/*", ezbartest.Fixture{File: "wttr/berlin.txt"})
+	fx.Handle("https://api.example.com:8443/**", ezbartest.Fixture{Body: []byte("0123456789"), Truncate: 4})
+	c := &ezbartest.Ctx{HTTPFunc: fx.Open}
+
+	if body, err := c.HTTPGet("https://wttr.in/Berlin?format=3"); err != nil || string(body) != "Berlin: ⛅️ +12°C\n" {
+		t.Errorf("routed file = %q, %v", body, err)
+	}
+	if _, err := c.HTTPGet("https://wttr.in/Nowhere?format=3"); err == nil || err.Error() != "http 404 Not Found" {
+		t.Errorf("status fixture: err = %v, want the host's http 404 Not Found", err)
+	}
+
+	// a truncated body fails a whole fetch, and breaks a stream off after the cut.
+	if body, err := c.HTTPGet("https://api.example.com:8443/v1/quakes/today"); err == nil || body != nil {
+		t.Errorf("truncated HTTPGet = %q, %v; want an error", body, err)
+	}
+	r, err := c.HTTPOpen("https://api.example.com:8443/v1/quakes/today")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(r)
+	if string(got) != "0123" || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("truncated stream = %q, %v; want 0123 then io.ErrUnexpectedEOF", got, err)
+	}
+
+	for _, url := range []string{
+		"https://example.org/",               // not granted
+		"https://api.example.com/v1/quakes",  // the grant pins :8443
+		"https://api.example.com:443/quakes", // ...exactly
+		"http://evil.com/?https://wttr.in/",  // the host is the authority, not a substring
+	} {
+		if _, err := c.HTTPGet(url); err == nil || !strings.HasPrefix(err.Error(), "capability denied: network host") {
+			t.Errorf("%s: err = %v, want a denial", url, err)
+		}
+	}
+	// a port-less grant covers any port, in any case.
+	fx.Handle("https://WTTR.in:443/*", ezbartest.Fixture{})
+	if _, err := c.HTTPGet("https://WTTR.in:443/Berlin"); err != nil {
+		t.Errorf("wttr.in grant on :443: %v", err)
+	}
+	if want := []string{"https://wttr.in/Berlin?format=3", "https://wttr.in/Nowhere?format=3"}; len(c.Requests) < 2 || c.Requests[0] != want[0] || c.Requests[1] != want[1] {
+		t.Errorf("Requests = %q, want to start %q", c.Requests, want)
+	}
+}
+
+func TestFixturesDelay(t *testing.T) {
+	fx := ezbartest.NewFixtures(t, "*")
+	fx.Handle("https://slow.example/*", ezbartest.Fixture{Body: []byte("ok"), Delay: 3 * time.Second})
+	fx.Handle("https://stuck.example/*", ezbartest.Fixture{Delay: ezbartest.HTTPTimeout})
+	c := &ezbartest.Ctx{HTTPFunc: fx.Open}
+	start := time.Now()
+	if body, err := c.HTTPGet("https://slow.example/a"); err != nil || string(body) != "ok" {
+		t.Errorf("slow = %q, %v", body, err)
+	}
+	if _, err := c.HTTPGet("https://stuck.example/a"); err == nil || err.Error() != "operation timed out" {
+		t.Errorf("stuck: err = %v, want a timeout", err)
+	}
+	if fx.Waited != 33*time.Second {
+		t.Errorf("Waited = %v, want 33s", fx.Waited)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("delays should be virtual")
+	}
+}
+
+func TestFixturesUnrouted(t *testing.T) {
+	ft := &fakeT{TB: t}
+	fx := ezbartest.NewFixtures(ft, "wttr.in")
+	fx.Record = false
+	if _, err := fx.Open("https://wttr.in/Paris"); err == nil || !ft.failed {
+		t.Errorf("an unrouted, unrecorded URL: err = %v, failed = %v; want both", err, ft.failed)
+	}
+}
+
+func TestFixturesRecord(t *testing.T) {
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		io.WriteString(w, "live "+r.URL.RequestURI())
+	}))
+	dir := t.TempDir()
+	url := srv.URL + "/quakes?min=4.5&day=1"
+
+	rec := ezbartest.NewFixtures(t, "127.0.0.1")
+	rec.Dir, rec.Record = dir, true
+	for range 2 {
+		r, err := rec.Open(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body, _ := io.ReadAll(r); string(body) != "live /quakes?min=4.5&day=1" {
+			t.Errorf("recorded body = %q", body)
+		}
+	}
+	if hits != 1 {
+		t.Errorf("live hits = %d, want 1: a recording is made once", hits)
+	}
+	srv.Close()
+
+	// offline from here on: the recording serves it.
+	off := ezbartest.NewFixtures(t, "127.0.0.1")
+	off.Dir, off.Record = dir, false
+	c := &ezbartest.Ctx{HTTPFunc: off.Open}
+	if body, err := c.HTTPGet(url); err != nil || string(body) != "live /quakes?min=4.5&day=1" {
+		t.Errorf("replayed = %q, %v", body, err)
+	}
+}
@@ -0,0 +1,78 @@
+//go:build !wasm
+
+package ezbartest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+)
+
+var updateGolden = flag.Bool("update-golden", false, "rewrite ezbartest golden files instead of comparing")
+
+// AssertGolden compares r's [ezbar.Render.Dump] with testdata/<name>.golden
+// and fails t with a line diff if they differ. Run the test with
+// -update-golden to (re)write the file from r, then review the change like
+// any other diff.
+func AssertGolden(t testing.TB, name string, r ezbar.Render) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	got := r.Dump() + "\n"
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update-golden to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s: render differs from %s (-want +got):\n%s", name, path, diff(string(want), got))
+	}
+}
+
+// diff is a line diff of a and b: a longest common subsequence, with the lines
+// only in a marked "-" and those only in b "+".
+func diff(a, b string) string {
+	x := strings.Split(strings.TrimSuffix(a, "\n"), "\n")
+	y := strings.Split(strings.TrimSuffix(b, "\n"), "\n")
+	// lcs[i][j] is the LCS length of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var out strings.Builder
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			out.WriteString("  " + x[i] + "\n")
+			i, j = i+1, j+1
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			out.WriteString("- " + x[i] + "\n")
+			i++
+		default:
+			out.WriteString("+ " + y[j] + "\n")
+			j++
+		}
+	}
+	return out.String()
+}
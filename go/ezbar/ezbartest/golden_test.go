@@ -0,0 +1,11 @@
+package ezbartest
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	want := "row{\n  text(\"a\",fg)\n  text(\"b\",fg)\n}\n"
+	got := "row{\n  text(\"a\",ok)\n  text(\"b\",fg)\n}\n"
+	if d := diff(want, got); d != "  row{\n-   text(\"a\",fg)\n+   text(\"a\",ok)\n    text(\"b\",fg)\n  }\n" {
+		t.Errorf("diff =\n%s", d)
+	}
+}
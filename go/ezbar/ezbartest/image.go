@@ -0,0 +1,120 @@
+//go:build !wasm
+
+package ezbartest
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+	"github.com/birdayz/ezbar/go/ezbar/snapshot"
+)
+
+// ImageTolerance is how far (0-255) any channel of a pixel may drift from the
+// golden before [AssertImage] counts it as changed: enough to absorb
+// antialiasing noise, far too little to hide a moved or recoloured widget.
+const ImageTolerance = 8
+
+// AssertImage compares got with the PNG testdata/<name>.png and fails t if
+// any pixel differs by more than [ImageTolerance] or the sizes differ. On a
+// mismatch it writes the actual image and a diff (changed pixels red over a
+// dimmed copy of the golden) to a temp dir and names them in the failure.
+// Run the test with -update-golden to (re)write the golden from got.
+func AssertImage(t testing.TB, name string, got image.Image) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".png")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := writePNG(path, got); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update-golden to create it)", err)
+	}
+	want, err := png.Decode(f)
+	f.Close()
+	if err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+	if want.Bounds().Size() != got.Bounds().Size() {
+		t.Errorf("%s: image is %v, golden %s is %v", name, got.Bounds().Size(), path, want.Bounds().Size())
+		saveFailure(t, name, got, nil)
+		return
+	}
+	if n, diff := diffImages(want, got); n > 0 {
+		t.Errorf("%s: %d pixels differ from %s", name, n, path)
+		saveFailure(t, name, got, diff)
+	}
+}
+
+// AssertSnapshot draws r with [snapshot.Image] and checks it with
+// [AssertImage].
+func AssertSnapshot(t testing.TB, name string, r ezbar.Render, o snapshot.Options) {
+	t.Helper()
+	AssertImage(t, name, snapshot.Image(r, o))
+}
+
+// diffImages counts the pixels of got more than ImageTolerance off want (same
+// size) and paints them red over a dimmed want.
+func diffImages(want, got image.Image) (int, *image.NRGBA) {
+	wb, gb := want.Bounds(), got.Bounds()
+	diff := image.NewNRGBA(image.Rect(0, 0, wb.Dx(), wb.Dy()))
+	n := 0
+	for y := range wb.Dy() {
+		for x := range wb.Dx() {
+			w := color.NRGBAModel.Convert(want.At(wb.Min.X+x, wb.Min.Y+y)).(color.NRGBA)
+			g := color.NRGBAModel.Convert(got.At(gb.Min.X+x, gb.Min.Y+y)).(color.NRGBA)
+			if far(w.R, g.R) || far(w.G, g.G) || far(w.B, g.B) || far(w.A, g.A) {
+				n++
+				diff.SetNRGBA(x, y, color.NRGBA{255, 0, 0, 255})
+				continue
+			}
+			diff.SetNRGBA(x, y, color.NRGBA{w.R / 3, w.G / 3, w.B / 3, 255})
+		}
+	}
+	return n, diff
+}
+
+func far(a, b uint8) bool { return max(a, b)-min(a, b) > ImageTolerance }
+
+// saveFailure keeps the actual image (and the diff, if any) for a look.
+func saveFailure(t testing.TB, name string, got image.Image, diff image.Image) {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "ezbartest-"+name+"-")
+	if err != nil {
+		t.Log(err)
+		return
+	}
+	files := []string{filepath.Join(dir, "got.png")}
+	err = writePNG(files[0], got)
+	if diff != nil && err == nil {
+		files = append(files, filepath.Join(dir, "diff.png"))
+		err = writePNG(files[1], diff)
+	}
+	if err != nil {
+		t.Log(err)
+		return
+	}
+	t.Logf("wrote %v", files)
+}
+
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
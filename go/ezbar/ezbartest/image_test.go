@@ -0,0 +1,30 @@
+//go:build !wasm
+
+package ezbartest
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestDiffImages(t *testing.T) {
+	want := image.NewNRGBA(image.Rect(0, 0, 3, 1))
+	got := image.NewNRGBA(image.Rect(0, 0, 3, 1))
+	for x := range 3 {
+		want.SetNRGBA(x, 0, color.NRGBA{100, 100, 100, 255})
+	}
+	got.SetNRGBA(0, 0, color.NRGBA{100, 100, 100, 255})
+	got.SetNRGBA(1, 0, color.NRGBA{100 + ImageTolerance, 100, 100, 255}) // noise
+	got.SetNRGBA(2, 0, color.NRGBA{100, 100 + ImageTolerance + 1, 100, 255})
+	n, diff := diffImages(want, got)
+	if n != 1 {
+		t.Errorf("%d pixels differ, want 1", n)
+	}
+	if c := diff.NRGBAAt(2, 0); c != (color.NRGBA{255, 0, 0, 255}) {
+		t.Errorf("changed pixel drawn %v, want red", c)
+	}
+	if c := diff.NRGBAAt(0, 0); c.R != 33 {
+		t.Errorf("unchanged pixel drawn %v, want the golden dimmed", c)
+	}
+}
@@ -0,0 +1,412 @@
+//go:build !wasm
+
+package ezbartest
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+)
+
+// Replay re-drives p through a trace recorded by [ezbar.Record] — a copy of
+// the bar's log, whose "ezbar-trace " lines it picks out, or the trace file —
+// and returns the Driver it used, for looking at the plugin afterwards
+// (d.View(), d.Ctx.Logs, …).
+//
+// The inputs (config, events, restored state, calls for the chip or popup)
+// are delivered in their recorded order, and every host call is answered with
+// what the host answered when the trace was made: HTTP and file reads by URL
+// or path, in order, the rest by call. A replay that asks the host for
+// something the recording didn't, leaves a recorded answer unasked, or gets a
+// different re-render decision from Update than was recorded has diverged,
+// and fails t at that point. The plugin must not read the clock or other
+// state outside the trace to replay deterministically.
+func Replay(t testing.TB, p ezbar.Plugin, trace io.Reader) *Driver {
+	t.Helper()
+	recs, err := parseTrace(trace)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	d := New(t, p)
+	h := &replayHost{Ctx: d.Ctx, t: t, answers: map[string][]record{}, reads: map[string][]record{}}
+	var inputs []record
+	for _, r := range recs {
+		switch r.kind {
+		case "init", "config", "restore", "view", "popup", "timer", "pointer", "feed", "done":
+			inputs = append(inputs, r)
+		case "chunk", "eof", "rerr":
+			h.reads[r.arg(0)] = append(h.reads[r.arg(0)], r)
+		case "http", "read", "open", "exec", "pick":
+			h.answers[r.kind+" "+r.arg(0)] = append(h.answers[r.kind+" "+r.arg(0)], r)
+		case "textsize", "fg", "sway", "tz", "clock":
+			h.answers[r.kind] = append(h.answers[r.kind], r)
+		default:
+			t.Fatalf("replay: trace line %d: unknown record %q", r.line, r.kind)
+		}
+	}
+	// as on the bar, the plugin sees only its WIT version's Ctx: a type
+	// assertion to a later CtxVN fails on replay as it did when recording.
+	d.host = struct{ ezbar.Ctx }{h}
+	ezbar.UseHost(h)
+	ezbar.UseClock(h.clock)
+
+	for i := 0; i < len(inputs); i++ {
+		r := inputs[i]
+		var ev ezbar.Event
+		switch r.kind {
+		case "init":
+			d.textSize = r.textSize(t)
+			d.Load(r.config())
+			continue
+		case "restore":
+			d.P.Restore([]byte(r.arg(0)))
+			continue
+		case "view":
+			d.View()
+			continue
+		case "popup":
+			d.Popup()
+			continue
+		case "done":
+			t.Fatalf("replay: trace line %d: done without an event", r.line)
+		case "timer":
+			ev = ezbar.Event{Kind: ezbar.EvTimer}
+		case "pointer":
+			ev = ezbar.Event{Kind: ezbar.EvPointer, PointerID: r.arg(0), PointerKind: ezbar.PointerKind(r.int(t, 1)), Delta: float32(r.float(t, 2))}
+		case "feed":
+			ev = ezbar.Event{Kind: ezbar.EvFeed, Feed: ezbar.FeedKind(r.int(t, 0)), Value: r.float(t, 1)}
+		case "config":
+			d.textSize = r.textSize(t)
+			ev = d.configEvent(r.config())
+		}
+		redraw := d.Update(ev)
+		if i+1 < len(inputs) && inputs[i+1].kind == "done" {
+			i++
+			if want := inputs[i].arg(0) == "true"; redraw != want {
+				t.Errorf("replay: trace line %d: Update(%s) = %v, recorded %v", r.line, r.kind, redraw, want)
+			}
+		}
+	}
+	for _, q := range h.answers {
+		for _, r := range q {
+			t.Errorf("replay: trace line %d: %s %q was recorded but never asked for", r.line, r.kind, r.arg(0))
+		}
+	}
+	return d
+}
+
+// record is one trace line: its kind word and its fields, strings unquoted.
+type record struct {
+	line int
+	kind string
+	f    []string
+}
+
+// arg is field i, or "" past the end.
+func (r record) arg(i int) string {
+	if i < len(r.f) {
+		return r.f[i]
+	}
+	return ""
+}
+
+// config is an init or config record's table.
+func (r record) config() map[string]string {
+	config := map[string]string{}
+	for j := 1; j+1 < len(r.f); j += 2 {
+		config[r.f[j]] = r.f[j+1]
+	}
+	return config
+}
+
+// textSize is an init or config record's text size, the one the SDK read for
+// Em, as the Driver's source for it; the plugin's own TextSize calls are
+// answered from their textsize records.
+func (r record) textSize(t testing.TB) func() float32 {
+	px := float32(r.float(t, 0))
+	return func() float32 { return px }
+}
+
+func (r record) int(t testing.TB, i int) int {
+	t.Helper()
+	n, err := strconv.Atoi(r.arg(i))
+	if err != nil {
+		t.Errorf("replay: trace line %d: %s field %d: %v", r.line, r.kind, i, err)
+	}
+	return n
+}
+
+func (r record) float(t testing.TB, i int) float64 {
+	t.Helper()
+	v, err := strconv.ParseFloat(r.arg(i), 64)
+	if err != nil {
+		t.Errorf("replay: trace line %d: %s field %d: %v", r.line, r.kind, i, err)
+	}
+	return v
+}
+
+const tracePrefix = "ezbar-trace "
+
+// parseTrace reads a trace file, or the trace lines of a log if there are
+// any.
+func parseTrace(trace io.Reader) ([]record, error) {
+	var lines []string
+	logged := false
+	sc := bufio.NewScanner(trace)
+	sc.Buffer(nil, 1<<30)
+	for sc.Scan() {
+		line := sc.Text()
+		if i := strings.Index(line, tracePrefix); i >= 0 {
+			if !logged {
+				logged, lines = true, nil // a log: only the trace lines count
+			}
+			line = line[i+len(tracePrefix):]
+		} else if logged {
+			line = ""
+		}
+		lines = append(lines, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	var recs []record
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		f, err := fields(line)
+		if err != nil {
+			return nil, fmt.Errorf("trace line %d: %v", i+1, err)
+		}
+		recs = append(recs, record{line: i + 1, kind: f[0], f: f[1:]})
+	}
+	return recs, nil
+}
+
+// fields splits a record into bare words and unquoted Go strings.
+func fields(line string) ([]string, error) {
+	var f []string
+	for {
+		line = strings.TrimLeft(line, " ")
+		if line == "" {
+			return f, nil
+		}
+		if line[0] != '"' {
+			word, rest, _ := strings.Cut(line, " ")
+			f, line = append(f, word), rest
+			continue
+		}
+		q, err := strconv.QuotedPrefix(line)
+		if err != nil {
+			return nil, err
+		}
+		s, _ := strconv.Unquote(q)
+		f, line = append(f, s), line[len(q):]
+	}
+}
+
+// replayHost answers host calls from the trace; the calls that answer
+// nothing go to the Driver's Ctx, which records them as usual.
+type replayHost struct {
+	*Ctx
+	t       testing.TB
+	answers map[string][]record // by kind, or kind and URL/path/program/prompt
+	reads   map[string][]record // by stream number
+}
+
+// next pops the next recorded answer for key, failing t if there is none.
+func (h *replayHost) next(key string) (record, bool) {
+	q := h.answers[key]
+	if len(q) == 0 {
+		h.t.Errorf("replay: %s: not in the trace (the plugin diverged from the recording)", key)
+		return record{}, false
+	}
+	h.answers[key] = q[1:]
+	return q[0], true
+}
+
+var errDiverged = errors.New("replay: not in the trace")
+
+// clock answers the SDK's reads of the time from their clock records.
+func (h *replayHost) clock() time.Time {
+	r, ok := h.next("clock")
+	if !ok {
+		return time.Time{}
+	}
+	ns, err := strconv.ParseInt(r.arg(0), 10, 64)
+	if err != nil {
+		h.t.Errorf("replay: trace line %d: malformed clock record", r.line)
+	}
+	return time.Unix(0, ns)
+}
+
+// result reads an answer's outcome from f, its fields after the key: the n
+// fields after "ok", or the message after "err" as an error.
+func (h *replayHost) result(r record, f []string, n int) ([]string, error) {
+	switch {
+	case len(f) > n && f[0] == "ok":
+		return f[1:], nil
+	case len(f) > 1 && f[0] == "err":
+		return nil, errors.New(f[1])
+	}
+	h.t.Errorf("replay: trace line %d: malformed %s record", r.line, r.kind)
+	return nil, errDiverged
+}
+
+func (h *replayHost) TextSize() float32 {
+	r, ok := h.next("textsize")
+	if !ok {
+		return 14
+	}
+	return float32(r.float(h.t, 0))
+}
+
+// themeColors are the theme tokens by their names in a trace.
+var themeColors = map[string]ezbar.Color{
+	"fg": ezbar.Fg, "fg-dim": ezbar.FgDim, "accent": ezbar.Accent, "ok": ezbar.OK,
+	"warn": ezbar.Warn, "urgent": ezbar.Urgent, "bg": ezbar.Bg,
+}
+
+func (h *replayHost) Fg() ezbar.Color {
+	r, ok := h.next("fg")
+	if !ok {
+		return ezbar.Fg
+	}
+	switch r.arg(0) {
+	case "rgba":
+		return ezbar.RGBA(uint8(r.int(h.t, 1)), uint8(r.int(h.t, 2)), uint8(r.int(h.t, 3)), uint8(r.int(h.t, 4)))
+	case "token":
+		// a theme token newer than this SDK: no Color names it, so the
+		// replay can only stand in the primary text colour.
+		h.t.Logf("replay: trace line %d: fg is theme token %s, unknown to this SDK; using Fg", r.line, r.arg(1))
+		return ezbar.Fg
+	}
+	return themeColors[r.arg(0)]
+}
+
+func (h *replayHost) HTTPGet(url string) ([]byte, error) {
+	h.Requests = append(h.Requests, url)
+	r, ok := h.next("http " + url)
+	if !ok {
+		return nil, errDiverged
+	}
+	f, err := h.result(r, r.f[1:], 1)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(f[0]), nil
+}
+
+func (h *replayHost) HTTPOpen(url string) (io.ReadCloser, error) {
+	h.Requests = append(h.Requests, url)
+	r, ok := h.next("open " + url)
+	if !ok {
+		return nil, errDiverged
+	}
+	f, err := h.result(r, r.f[1:], 1)
+	if err != nil {
+		return nil, err
+	}
+	return &replayStream{h: h, id: f[0]}, nil
+}
+
+func (h *replayHost) ReadFile(path string) ([]byte, error) {
+	r, ok := h.next("read " + path)
+	switch {
+	case !ok || r.arg(1) == "denied":
+		return nil, &fs.PathError{Op: "read", Path: path, Err: fs.ErrPermission}
+	case r.arg(1) == "ok":
+		return []byte(r.arg(2)), nil
+	}
+	return nil, &fs.PathError{Op: "read", Path: path, Err: errors.New(r.arg(2))}
+}
+
+func (h *replayHost) SwaySnapshot() (ezbar.SwayState, error) {
+	r, ok := h.next("sway")
+	if !ok {
+		return ezbar.SwayState{}, errDiverged
+	}
+	f, err := h.result(r, r.f, 1) // sway has no key
+	if err != nil {
+		return ezbar.SwayState{}, err
+	}
+	s := ezbar.SwayState{Title: f[0]}
+	for j := 1; j+1 < len(f); j += 2 {
+		flags := f[j+1]
+		s.Workspaces = append(s.Workspaces, ezbar.SwayWorkspace{
+			Name:    f[j],
+			Focused: strings.Contains(flags, "f"),
+			Visible: strings.Contains(flags, "v"),
+			Urgent:  strings.Contains(flags, "u"),
+		})
+	}
+	return s, nil
+}
+
+func (h *replayHost) Exec(program string, args []string, stdin []byte) (ezbar.ExecOutput, error) {
+	r, ok := h.next("exec " + program)
+	if !ok {
+		return ezbar.ExecOutput{}, errDiverged
+	}
+	f, err := h.result(r, r.f[1:], 3)
+	if err != nil {
+		return ezbar.ExecOutput{}, err
+	}
+	code, _ := strconv.Atoi(f[0])
+	return ezbar.ExecOutput{Code: code, Stdout: []byte(f[1]), Stderr: []byte(f[2])}, nil
+}
+
+func (h *replayHost) Pick(prompt string, items []string, current int) (string, bool) {
+	r, ok := h.next("pick " + prompt)
+	if !ok || r.arg(1) != "ok" {
+		return "", false
+	}
+	return r.arg(2), true
+}
+
+func (h *replayHost) LocalTimezone() string {
+	r, ok := h.next("tz")
+	if !ok {
+		return "UTC"
+	}
+	return r.arg(0)
+}
+
+// replayStream plays back an HTTPOpen body's reads as recorded. A read with
+// a smaller buffer than the recorded one gets the chunk in pieces.
+type replayStream struct {
+	h    *replayHost
+	id   string
+	rest []byte
+}
+
+func (s *replayStream) Read(p []byte) (int, error) {
+	if len(s.rest) == 0 {
+		q := s.h.reads[s.id]
+		if len(q) == 0 {
+			return 0, io.EOF
+		}
+		r := q[0]
+		s.h.reads[s.id] = q[1:]
+		switch r.kind {
+		case "eof":
+			return 0, io.EOF
+		case "rerr":
+			return 0, errors.New(r.arg(1))
+		}
+		s.rest = []byte(r.arg(1))
+	}
+	n := copy(p, s.rest)
+	s.rest = s.rest[n:]
+	return n, nil
+}
+
+func (s *replayStream) Close() error { return nil }
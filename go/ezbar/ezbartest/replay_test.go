@@ -0,0 +1,201 @@
+package ezbartest_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+	"github.com/birdayz/ezbar/go/ezbar/ezbartest"
+	"github.com/birdayz/ezbar/go/internal/ezbar/plugin/events"
+	"github.com/birdayz/ezbar/go/internal/ezbar/plugin/plugin"
+	"go.bytecodealliance.org/cm"
+)
+
+// feedReader touches every kind of trace record: a fetch, a file, a streamed
+// body, a feed, a click on a MouseArea id and on an OnClick area, a config
+// change and saved state.
+type feedReader struct {
+	ezbar.Base
+	url    string
+	status string
+	bat    string
+	size   int
+	load   float64
+	clicks int
+}
+
+func (f *feedReader) Load(config map[string]string) { f.url = config["url"] }
+
+func (f *feedReader) Update(ctx ezbar.Ctx, ev ezbar.Event) bool {
+	switch ev.Kind {
+	case ezbar.EvConfig:
+		f.Load(ev.Config)
+		return ev.Diff.Touches("url")
+	case ezbar.EvTimer:
+		ctx.SetTimeout(5_000)
+		if body, err := ctx.HTTPGet(f.url + "/status"); err == nil {
+			f.status = string(body)
+		} else {
+			f.status = "error: " + err.Error()
+		}
+		if data, err := ctx.ReadFile("/sys/class/power_supply/BAT0/capacity"); err == nil {
+			f.bat = strings.TrimSpace(string(data))
+		}
+		// streamed where the targeted WIT version has HTTPOpen (v6).
+		if c, ok := ctx.(ezbar.CtxV6); ok {
+			if r, err := c.HTTPOpen(f.url + "/feed"); err == nil {
+				data, _ := io.ReadAll(r)
+				r.Close()
+				f.size = len(data)
+			}
+		}
+		return true
+	case ezbar.EvFeed:
+		f.load = ev.Value
+		return ev.Value > 0.5
+	case ezbar.EvPointer:
+		f.clicks++
+		return true
+	}
+	return false
+}
+
+func (f *feedReader) View() ezbar.Render {
+	return ezbar.OnClick(ezbar.Text(f.status+" "+f.bat+"% "+strconv.Itoa(f.size)+"B "+
+		strconv.FormatFloat(f.load, 'f', 2, 64)+" ×"+strconv.Itoa(f.clicks)), f.reset)
+}
+
+func (f *feedReader) reset(k ezbar.PointerKind, _ float32) bool {
+	f.clicks = 0
+	return k == ezbar.RightPress
+}
+
+func (f *feedReader) SaveState() []byte { return []byte(strconv.Itoa(f.clicks)) }
+func (f *feedReader) Restore(state []byte) {
+	f.clicks, _ = strconv.Atoi(string(state))
+}
+
+// record runs f through the component exports under ezbar.Record(path), with
+// host standing in for the bar, the way a real session on the bar would.
+func record(t *testing.T, f ezbar.Plugin, path string, host *ezbartest.Ctx) {
+	t.Helper()
+	ezbar.UseHost(host)
+	t.Cleanup(func() { ezbar.UseHost(nil); ezbar.Register(&feedReader{}) })
+	ezbar.Register(f, ezbar.Record(path))
+	plugin.Exports.Restore(cm.ToList([]byte("2")))
+	plugin.Exports.Init(cm.ToList([][2]string{{"url", "https://example.com"}}))
+	plugin.Exports.Update(events.EventTimer())
+	plugin.Exports.Update(events.EventFeed(events.FeedSample{Feed: ezbar.FeedCPU, Value: 0.25}))
+	plugin.Exports.Update(events.EventFeed(events.FeedSample{Feed: ezbar.FeedCPU, Value: 0.75}))
+	plugin.Exports.Update(events.EventPointer(events.PointerEvent{ID: "chip \"main\"", Kind: ezbar.Press}))
+	plugin.Exports.View()
+	plugin.Exports.Update(events.EventPointer(events.PointerEvent{ID: "~v0", Kind: ezbar.RightPress}))
+	plugin.Exports.Update(events.EventPointer(events.PointerEvent{ID: "chip \"main\"", Kind: ezbar.Press}))
+	plugin.Exports.Update(events.EventConfig(cm.ToList([][2]string{{"url", "https://example.com"}, {"theme", "dark"}})))
+	plugin.Exports.Update(events.EventConfig(cm.ToList([][2]string{{"url", "https://example.org"}})))
+	host.Fail("https://example.org/status", io.ErrUnexpectedEOF)
+	plugin.Exports.Update(events.EventTimer())
+}
+
+// bound is p's chip with its OnClick ids, as the bar and a Driver see it.
+func bound(p ezbar.Plugin) ezbar.Render {
+	var h ezbar.Handlers
+	return h.View(p.View())
+}
+
+func scriptedHost() *ezbartest.Ctx {
+	host := &ezbartest.Ctx{Files: map[string][]byte{"/sys/class/power_supply/BAT0/capacity": []byte("87\n")}}
+	host.Respond("https://example.com/status", []byte("up\n\x00 ok"))
+	host.Respond("https://example.com/feed", []byte(strings.Repeat("x", 2_000)))
+	return host
+}
+
+func TestRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace")
+	live := &feedReader{}
+	record(t, live, path, scriptedHost())
+
+	trace, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the replay gets no host at all: every answer comes from the trace.
+	d := ezbartest.Replay(t, &feedReader{}, strings.NewReader(string(trace)))
+	if got, want := d.View().Dump(), bound(live).Dump(); got != want {
+		t.Errorf("replayed view:\n%s\nrecorded:\n%s\ntrace:\n%s", got, want, trace)
+	}
+	if ms, _ := d.Ctx.Timeout(); ms != 5_000 {
+		t.Errorf("replayed timeout = %d, want 5000", ms)
+	}
+}
+
+func TestRecordToLog(t *testing.T) {
+	host := scriptedHost()
+	live := &feedReader{}
+	record(t, live, "", host)
+
+	// the log as the bar shows it: trace lines among the plugin's own.
+	var log strings.Builder
+	for _, l := range host.Logs {
+		log.WriteString("12:00:01 INFO feedreader: " + l + "\n")
+	}
+	log.WriteString("12:00:02 INFO feedreader: something else\n")
+	d := ezbartest.Replay(t, &feedReader{}, strings.NewReader(log.String()))
+	if got, want := d.View().Dump(), bound(live).Dump(); got != want {
+		t.Errorf("replayed view:\n%s\nrecorded:\n%s", got, want)
+	}
+}
+
+// drifted fetches from a different path than the recording asked.
+type drifted struct{ feedReader }
+
+func (d *drifted) Update(ctx ezbar.Ctx, ev ezbar.Event) bool {
+	if ev.Kind == ezbar.EvTimer {
+		ctx.HTTPGet(d.url + "/v2/status")
+	}
+	return d.feedReader.Update(ctx, ev)
+}
+
+func TestReplayDiverged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace")
+	record(t, &feedReader{}, path, scriptedHost())
+	trace, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ft := &fakeT{TB: t}
+	ezbartest.Replay(ft, &drifted{}, strings.NewReader(string(trace)))
+	if !ft.failed {
+		t.Error("a replay that fetches an unrecorded URL should fail")
+	}
+}
+
+// TestReplayClock replays Components' timer bookkeeping from the clock
+// readings in the trace, not the replay's own clock.
+func TestReplayClock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace")
+	ezbar.UseHost(&ezbartest.Ctx{})
+	t.Cleanup(func() { ezbar.UseHost(nil); ezbar.Register(&feedReader{}) })
+	live := &dash{}
+	ezbar.Register(live, ezbar.Record(path))
+	plugin.Exports.Init(cm.List[[2]string]{})
+	for range 3 {
+		plugin.Exports.Update(events.EventTimer())
+	}
+	trace, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(trace), "\nclock ") {
+		t.Fatalf("no clock records in the trace:\n%s", trace)
+	}
+	d := ezbartest.Replay(t, &dash{}, strings.NewReader(string(trace)))
+	got, want := d.P.(*dash).kids.Get("sec").(*every).ticks, live.kids.Get("sec").(*every).ticks
+	if got != want {
+		t.Errorf("replayed %d ticks, recorded %d", got, want)
+	}
+}
@@ -0,0 +1,304 @@
+//go:build !wasm
+
+package ezbartest
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+)
+
+// The host's cadence constants (crates/ezbar-wasm, RFC 0011/0012/0009).
+const (
+	MinTimer    = 100 * time.Millisecond // floor on a nonzero SetTimeout
+	Heartbeat   = 2 * time.Second        // legacy wake for a plugin that never arms
+	FeedBase    = time.Second            // feed sampling period, and the min-period floor
+	MinInterval = 16 * time.Millisecond  // gap after each pointer-driven call
+)
+
+const never = time.Duration(math.MaxInt64)
+
+// Series scripts a feed: the sampler calls it once per [FeedBase] tick with the
+// virtual time, and ok=false means no sample that tick (the host's sampler
+// came back empty).
+type Series func(at time.Duration) (value float64, ok bool)
+
+// Values is a Series that yields vs one per sample tick, then nothing.
+func Values(vs ...float64) Series {
+	i := 0
+	return func(time.Duration) (float64, bool) {
+		if i >= len(vs) {
+			return 0, false
+		}
+		i++
+		return vs[i-1], true
+	}
+}
+
+// Const is a Series that always yields v.
+func Const(v float64) Series {
+	return func(time.Duration) (float64, bool) { return v, true }
+}
+
+// Step is one event the simulator delivered.
+type Step struct {
+	At     time.Duration // virtual time since init
+	Event  ezbar.Event
+	Redraw bool // Update returned true, so View ran
+}
+
+// Sim runs a Plugin on a virtual clock with the host's drive loop (RFC 0011):
+// one bootstrap EvTimer right after Load; a plugin that never calls
+// SetTimeout keeps a ~2s heartbeat, restarted by every event; SetTimeout(ms)
+// is a one-shot floored at [MinTimer], consumed when it fires; SetTimeout(0)
+// cancels; a step that doesn't call it leaves the timer as it was. Feeds
+// (RFC 0012) are sampled every [FeedBase] from subscription, delivered no
+// faster than the subscribed period (floored at FeedBase), and only for the
+// kinds in Series — the rest count as ungranted. When several events are due
+// at once, a pointer event goes first, then a feed sample, then the timer.
+//
+// A simulated day runs in milliseconds:
+//
+//	s := ezbartest.NewSim(t, &Clock{})
+//	s.Init(nil)
+//	s.Run(24 * time.Hour)
+//	if n := len(s.Redraws()); n != 1+24*60 { ... } // the bootstrap tick, then once a minute
+type Sim struct {
+	*Driver
+
+	// Series scripts each granted feed. Set it before Init.
+	Series map[ezbar.FeedKind]Series
+
+	Now   time.Duration // virtual time since init
+	Steps []Step        // every delivered event, in order
+	Last  ezbar.Render  // the latest View
+
+	timer    simTimer
+	at       time.Duration // the armed deadline, for timerAt
+	ready    time.Duration // when the drive loop is back in its select
+	inputs   []input       // scheduled pointer events, by time
+	pending  []input       // sampled feed values not yet delivered
+	feeds    []*feedSub
+	timeouts int // Ctx.Timeouts already folded
+	subs     int // Ctx.Feeds already registered
+}
+
+type simTimer uint8
+
+const (
+	timerHeartbeat simTimer = iota
+	timerAt
+	timerIdle
+)
+
+type input struct {
+	at time.Duration
+	ev ezbar.Event
+}
+
+type feedSub struct {
+	kind     ezbar.FeedKind
+	series   Series
+	period   time.Duration
+	tick     time.Duration // next sampler tick
+	lastSent time.Duration // -1: never
+}
+
+// NewSim simulates p with an empty [Ctx]; script s.Ctx and s.Series, then
+// call Init. Now is the SDK's clock.
+func NewSim(t TB, p ezbar.Plugin) *Sim {
+	s := &Sim{Driver: New(t, p)}
+	ezbar.UseClock(func() time.Time { return epoch.Add(s.Now) })
+	return s
+}
+
+// Init loads config and delivers the bootstrap EvTimer at time 0.
+func (s *Sim) Init(config map[string]string) {
+	s.t.Helper()
+	s.Load(config)
+	s.fold()
+	s.step(ezbar.Event{Kind: ezbar.EvTimer})
+}
+
+// PointerAt schedules a pointer event on the mouse-area id at virtual time at
+// (not before Now). Scrolls queued back to back are summed into one event, as
+// the host coalesces them.
+func (s *Sim) PointerAt(at time.Duration, id string, kind ezbar.PointerKind, delta float32) {
+	ev := ezbar.Event{Kind: ezbar.EvPointer, PointerID: id, PointerKind: kind, Delta: delta}
+	i := sort.Search(len(s.inputs), func(i int) bool { return s.inputs[i].at > at })
+	s.inputs = append(s.inputs, input{})
+	copy(s.inputs[i+1:], s.inputs[i:])
+	s.inputs[i] = input{at: max(at, s.Now), ev: ev}
+}
+
+// Run advances the virtual clock by d, delivering every event that falls due.
+func (s *Sim) Run(d time.Duration) {
+	s.t.Helper()
+	end := s.Now + d
+	for {
+		at, kind := s.next(end)
+		if at > end {
+			s.Now = end
+			return
+		}
+		s.Now = at
+		switch kind {
+		case ezbar.EvPointer:
+			s.step(s.takeInput())
+		case ezbar.EvFeed:
+			ev := s.pending[0].ev
+			s.pending = s.pending[1:]
+			s.step(ev)
+		default:
+			if s.timer == timerAt {
+				s.timer = timerIdle // one-shot: consumed on fire
+			}
+			s.step(ezbar.Event{Kind: ezbar.EvTimer})
+		}
+	}
+}
+
+// Redraws is when each re-render happened.
+func (s *Sim) Redraws() []time.Duration {
+	var at []time.Duration
+	for _, st := range s.Steps {
+		if st.Redraw {
+			at = append(at, st.At)
+		}
+	}
+	return at
+}
+
+// Count is how many events of kind were delivered.
+func (s *Sim) Count(kind ezbar.EventKind) int {
+	n := 0
+	for _, st := range s.Steps {
+		if st.Event.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// next picks the loop's next wake at or before end, running the feed samplers
+// up to it; at > end means nothing is due by then.
+func (s *Sim) next(end time.Duration) (time.Duration, ezbar.EventKind) {
+	for {
+		tInput, tFeed, tTimer := never, never, never
+		if len(s.inputs) > 0 {
+			tInput = s.inputs[0].at
+		}
+		if len(s.pending) > 0 {
+			tFeed = s.pending[0].at
+		}
+		switch s.timer {
+		case timerHeartbeat:
+			tTimer = s.ready + Heartbeat
+		case timerAt:
+			tTimer = s.at
+		}
+		wake := max(s.ready, min(tInput, tFeed, tTimer))
+
+		var sampler *feedSub
+		for _, f := range s.feeds {
+			if sampler == nil || f.tick < sampler.tick {
+				sampler = f
+			}
+		}
+		if sampler != nil && sampler.tick <= wake && sampler.tick <= end {
+			s.sample(sampler)
+			continue
+		}
+		switch {
+		case wake == never:
+			return wake, 0
+		case tInput <= wake:
+			return wake, ezbar.EvPointer
+		case tFeed <= wake:
+			return wake, ezbar.EvFeed
+		default:
+			return wake, ezbar.EvTimer
+		}
+	}
+}
+
+// sample runs f's sampler tick: a value, if the series has one and f is due.
+func (s *Sim) sample(f *feedSub) {
+	at := f.tick
+	f.tick += FeedBase
+	v, ok := f.series(at)
+	if !ok || (f.lastSent >= 0 && at-f.lastSent < f.period) {
+		return
+	}
+	f.lastSent = at
+	s.pending = append(s.pending, input{at: at, ev: ezbar.Event{Kind: ezbar.EvFeed, Feed: f.kind, Value: v}})
+}
+
+// takeInput pops the next pointer event, summing a leading run of scrolls that
+// are already queued.
+func (s *Sim) takeInput() ezbar.Event {
+	ev := s.inputs[0].ev
+	s.inputs = s.inputs[1:]
+	if ev.PointerKind != ezbar.Scroll {
+		return ev
+	}
+	for len(s.inputs) > 0 && s.inputs[0].at <= s.Now && s.inputs[0].ev.PointerKind == ezbar.Scroll {
+		ev.Delta += s.inputs[0].ev.Delta
+		s.inputs = s.inputs[1:]
+	}
+	return ev
+}
+
+// step delivers ev at Now, then folds what the plugin asked for.
+func (s *Sim) step(ev ezbar.Event) {
+	s.t.Helper()
+	redraw := s.Update(ev)
+	if redraw {
+		s.Last = s.View()
+	}
+	s.Steps = append(s.Steps, Step{At: s.Now, Event: ev, Redraw: redraw})
+	s.fold()
+	s.ready = s.Now
+	if ev.Kind == ezbar.EvPointer {
+		s.ready += MinInterval
+	}
+}
+
+// fold applies the SetTimeout (last one wins) and FeedSubscribe calls the
+// plugin made since the last fold.
+func (s *Sim) fold() {
+	if ts := s.Ctx.Timeouts[s.timeouts:]; len(ts) > 0 {
+		switch ms := ts[len(ts)-1]; ms {
+		case 0:
+			s.timer = timerIdle
+		default:
+			s.timer, s.at = timerAt, s.Now+max(time.Duration(ms)*time.Millisecond, MinTimer)
+		}
+		s.timeouts = len(s.Ctx.Timeouts)
+	}
+	for _, sub := range s.Ctx.Feeds[s.subs:] {
+		period := max(time.Duration(sub.MinPeriodMs)*time.Millisecond, FeedBase)
+		series, granted := s.Series[sub.Feed]
+		if !granted {
+			s.t.Logf("ezbartest: feed %v has no Series (not granted) — never delivered", sub.Feed)
+			continue
+		}
+		if f := s.feed(sub.Feed); f != nil {
+			f.period = period // re-subscribing only updates the period
+			continue
+		}
+		s.feeds = append(s.feeds, &feedSub{kind: sub.Feed, series: series, period: period, tick: s.Now + FeedBase, lastSent: -1})
+	}
+	s.subs = len(s.Ctx.Feeds)
+}
+
+func (s *Sim) feed(kind ezbar.FeedKind) *feedSub {
+	for _, f := range s.feeds {
+		if f.kind == kind {
+			return f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package ezbartest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+	"github.com/birdayz/ezbar/go/ezbar/ezbartest"
+)
+
+// minuteClock redraws once a minute; cancel makes it stop on a click.
+type minuteClock struct {
+	ezbar.Base
+	arm uint32
+}
+
+func (c *minuteClock) Update(ctx ezbar.Ctx, ev ezbar.Event) bool {
+	switch ev.Kind {
+	case ezbar.EvTimer:
+		ctx.SetTimeout(c.arm)
+		return true
+	case ezbar.EvPointer:
+		ctx.SetTimeout(0)
+	}
+	return false
+}
+
+func (c *minuteClock) View() ezbar.Render { return ezbar.Text("12:34") }
+
+func TestSimOncePerMinute(t *testing.T) {
+	s := ezbartest.NewSim(t, &minuteClock{arm: 60_000})
+	s.Init(nil)
+	s.Run(24 * time.Hour)
+	r := s.Redraws()
+	if len(r) != 24*60+1 { // the bootstrap tick at 0, then one a minute
+		t.Fatalf("%d redraws in a day, want %d", len(r), 24*60+1)
+	}
+	for i, at := range r {
+		if at != time.Duration(i)*time.Minute {
+			t.Fatalf("redraw %d at %v, want %v", i, at, time.Duration(i)*time.Minute)
+		}
+	}
+}
+
+func TestSimFloorAndCancel(t *testing.T) {
+	s := ezbartest.NewSim(t, &minuteClock{arm: 1})
+	s.Init(nil)
+	s.Run(time.Second)
+	if n := s.Count(ezbar.EvTimer); n != 11 { // 0, 100ms, …, 1s
+		t.Errorf("%d ticks in 1s with SetTimeout(1), want 11 (100ms floor)", n)
+	}
+	s.PointerAt(s.Now, "chip", ezbar.Press, 0)
+	s.Run(time.Hour)
+	if n := s.Count(ezbar.EvTimer); n != 11 {
+		t.Errorf("%d ticks after SetTimeout(0), want still 11", n)
+	}
+}
+
+// legacy never arms; it only counts what it gets.
+type legacy struct{ ezbar.Base }
+
+func (legacy) Update(ezbar.Ctx, ezbar.Event) bool { return true }
+func (legacy) View() ezbar.Render                 { return ezbar.Text("") }
+
+func TestSimHeartbeat(t *testing.T) {
+	s := ezbartest.NewSim(t, legacy{})
+	s.Init(nil)
+	s.PointerAt(3*time.Second, "x", ezbar.Press, 0) // restarts the heartbeat
+	s.Run(10 * time.Second)
+	var ticks []time.Duration
+	for _, st := range s.Steps {
+		if st.Event.Kind == ezbar.EvTimer {
+			ticks = append(ticks, st.At)
+		}
+	}
+	want := []time.Duration{0, 2 * time.Second, 3*time.Second + 2016*time.Millisecond, 7*time.Second + 16*time.Millisecond, 9*time.Second + 16*time.Millisecond}
+	if len(ticks) != len(want) {
+		t.Fatalf("ticks at %v, want %v", ticks, want)
+	}
+	for i := range want {
+		if ticks[i] != want[i] {
+			t.Fatalf("ticks at %v, want %v", ticks, want)
+		}
+	}
+}
+
+// gauge subscribes to cpu on its first tick and keeps the latest sample.
+type gauge struct {
+	ezbar.Base
+	period uint32
+	last   float64
+}
+
+func (g *gauge) Update(ctx ezbar.Ctx, ev ezbar.Event) bool {
+	switch ev.Kind {
+	case ezbar.EvTimer:
+		ctx.FeedSubscribe(ezbar.FeedCPU, g.period)
+		ctx.FeedSubscribe(ezbar.FeedBattery, g.period)
+		ctx.SetTimeout(0)
+	case ezbar.EvFeed:
+		g.last = ev.Value
+		return true
+	}
+	return false
+}
+
+func (g *gauge) View() ezbar.Render { return ezbar.Text("") }
+
+func TestSimFeeds(t *testing.T) {
+	s := ezbartest.NewSim(t, &gauge{period: 10}) // clamped up to 1s
+	s.Series = map[ezbar.FeedKind]ezbartest.Series{ezbar.FeedCPU: ezbartest.Values(1, 2, 3)}
+	s.Init(nil)
+	s.Run(10 * time.Second)
+	if n := s.Count(ezbar.EvFeed); n != 3 {
+		t.Errorf("%d feed events, want 3 (one per second, then the series ends; battery ungranted)", n)
+	}
+	if got := s.P.(*gauge).last; got != 3 {
+		t.Errorf("last sample %v, want 3", got)
+	}
+
+	s = ezbartest.NewSim(t, &gauge{period: 2500})
+	s.Series = map[ezbar.FeedKind]ezbartest.Series{ezbar.FeedCPU: ezbartest.Const(50)}
+	s.Init(nil)
+	s.Run(10 * time.Second)
+	var at []time.Duration
+	for _, st := range s.Steps {
+		if st.Event.Kind == ezbar.EvFeed {
+			at = append(at, st.At)
+		}
+	}
+	// sampled every 1s, sent when 2.5s have passed since the last send
+	want := []time.Duration{1 * time.Second, 4 * time.Second, 7 * time.Second, 10 * time.Second}
+	if len(at) != len(want) || at[0] != want[0] || at[1] != want[1] || at[2] != want[2] || at[3] != want[3] {
+		t.Errorf("feed at %v, want %v", at, want)
+	}
+}
+
+func TestSimScrollCoalescing(t *testing.T) {
+	s := ezbartest.NewSim(t, legacy{})
+	s.Init(nil)
+	s.PointerAt(time.Second, "vol", ezbar.Scroll, 1)
+	s.PointerAt(time.Second, "vol", ezbar.Scroll, 2)
+	s.PointerAt(time.Second, "vol", ezbar.Press, 0)
+	s.Run(1100 * time.Millisecond)
+	var got []ezbar.Event
+	for _, st := range s.Steps {
+		if st.Event.Kind == ezbar.EvPointer {
+			got = append(got, st.Event)
+		}
+	}
+	if len(got) != 2 || got[0].PointerKind != ezbar.Scroll || got[0].Delta != 3 || got[1].PointerKind != ezbar.Press {
+		t.Errorf("pointer events %+v, want one Scroll(3) then a Press", got)
+	}
+}
+
+// every is a Component that ticks once per period.
+type every struct {
+	period uint32
+	ticks  int
+}
+
+func (e *every) Update(ctx ezbar.Ctx, ev ezbar.Event) bool {
+	if ev.Kind != ezbar.EvTimer {
+		return false
+	}
+	e.ticks++
+	ctx.SetTimeout(e.period)
+	return true
+}
+
+func (e *every) View() ezbar.Render          { return ezbar.Text("") }
+func (e *every) Popup() (ezbar.Render, bool) { return ezbar.Render{}, false }
+
+// dash runs a once-a-minute and a once-a-second child off the one host timer.
+type dash struct {
+	ezbar.Base
+	kids ezbar.Components
+}
+
+func (d *dash) Load(map[string]string) {
+	d.kids.Add("min", &every{period: 60_000})
+	d.kids.Add("sec", &every{period: 1_000})
+}
+
+func (d *dash) Update(ctx ezbar.Ctx, ev ezbar.Event) bool { return d.kids.Update(ctx, ev) }
+func (d *dash) View() ezbar.Render                        { return ezbar.Row(d.kids.View("min"), d.kids.View("sec")) }
+
+func TestSimComponents(t *testing.T) {
+	d := &dash{}
+	s := ezbartest.NewSim(t, d)
+	s.Init(nil)
+	s.Run(time.Hour)
+	mins, secs := d.kids.Get("min").(*every).ticks, d.kids.Get("sec").(*every).ticks
+	if mins != 61 || secs != 3601 {
+		t.Errorf("in an hour: %d minute ticks, %d second ticks; want 61, 3601", mins, secs)
+	}
+	if n := s.Count(ezbar.EvTimer); n != 3601 {
+		t.Errorf("%d host timers, want 3601: the minute rides the second's", n)
+	}
+}
+
+// poller ticks every second and refreshes every five minutes, on named timers.
+type poller struct {
+	ezbar.Base
+	ticks, refreshes int
+}
+
+func (p *poller) Update(ctx ezbar.Ctx, ev ezbar.Event) bool {
+	switch ev.Timer {
+	case "":
+		ctx.Every("tick", time.Second)
+		ctx.After("refresh", 5*time.Minute)
+	case "tick":
+		p.ticks++
+	case "refresh":
+		p.refreshes++
+		ctx.After("refresh", 5*time.Minute)
+	}
+	return true
+}
+
+func (p *poller) View() ezbar.Render { return ezbar.Text("") }
+
+func TestSimNamedTimers(t *testing.T) {
+	p := &poller{}
+	s := ezbartest.NewSim(t, p)
+	s.Init(nil)
+	s.Run(time.Hour)
+	if p.ticks != 3600 || p.refreshes != 12 {
+		t.Errorf("in an hour: %d ticks, %d refreshes; want 3600, 12", p.ticks, p.refreshes)
+	}
+	if n := s.Count(ezbar.EvTimer); n != 3601 {
+		t.Errorf("%d host timers, want 3601: the refreshes ride the ticks", n)
+	}
+}
@@ -0,0 +1,74 @@
+package ezbar
+
+import (
+	"bytes"
+	"io/fs"
+	"path"
+	"time"
+)
+
+// FS adapts ctx's ReadFile to an fs.FS rooted at "/", for the standard
+// helpers (fs.ReadFile, fs.Stat, …). Names are fs-style (unrooted,
+// slash-separated); "a/b" reads "/a/b". Like ReadFile it's denied on today's
+// hosts, which don't implement the read-file grant yet: every Open fails with
+// fs.ErrPermission.
+//
+// Every Open reads the whole file through the host — the sandbox has no file
+// handles — so keep to small status files. The host has no directory listing:
+// a directory can't be opened or read, but fs.Stat and fs.WalkDir rooted at a
+// granted file work. A denied path is an error wrapping fs.ErrPermission.
+func FS(ctx CtxV1) fs.FS { return hostFS{ctx} }
+
+type hostFS struct{ ctx CtxV1 }
+
+func (h hostFS) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+	data, err := h.ctx.ReadFile("/" + name)
+	if pe, ok := err.(*fs.PathError); ok {
+		pe.Path = name // report the name the caller used, as os.DirFS does
+	}
+	return data, err
+}
+
+func (h hostFS) Open(name string) (fs.File, error) {
+	data, err := h.ReadFile(name)
+	if err != nil {
+		if pe, ok := err.(*fs.PathError); ok {
+			pe.Op = "open"
+		}
+		return nil, err
+	}
+	return &memFile{Reader: bytes.NewReader(data), info: fileInfo{name: path.Base(name), size: int64(len(data))}}, nil
+}
+
+func (h hostFS) Stat(name string) (fs.FileInfo, error) {
+	f, err := h.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return f.Stat()
+}
+
+// memFile is an opened file: its contents were read whole at Open.
+type memFile struct {
+	*bytes.Reader
+	info fileInfo
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFile) Close() error               { return nil }
+
+// fileInfo describes a [memFile]; the host reports no mode or mtime.
+type fileInfo struct {
+	name string
+	size int64
+}
+
+func (i fileInfo) Name() string       { return i.name }
+func (i fileInfo) Size() int64        { return i.size }
+func (i fileInfo) Mode() fs.FileMode  { return 0o444 }
+func (i fileInfo) ModTime() time.Time { return time.Time{} }
+func (i fileInfo) IsDir() bool        { return false }
+func (i fileInfo) Sys() any           { return nil }
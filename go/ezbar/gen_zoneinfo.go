@@ -0,0 +1,185 @@
+//go:build ignore
+
+// gen_zoneinfo writes zoneinfo_table.go: every zone in the Go toolchain's
+// lib/time/zoneinfo.zip, trimmed to its POSIX TZ rule (the TZif footer) plus,
+// for the few zones whose future is precomputed as transitions the rule
+// doesn't predict (Africa/Casablanca's Ramadan switches), the transitions from
+// the one in effect at generation time on. The history is dropped on purpose —
+// a bar renders "now", and the table is ~25 KiB where the full database is
+// ~400 KiB. Run via `go generate`.
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+func main() {
+	zr, err := zip.OpenReader(filepath.Join(runtime.GOROOT(), "lib", "time", "zoneinfo.zip"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer zr.Close()
+
+	now := time.Now().Unix()
+	var lines []string
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			log.Fatal(err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+		rule, ok := footer(data)
+		if !ok {
+			log.Printf("skip %s: no TZ footer", f.Name)
+			continue
+		}
+		line := f.Name + " " + rule
+		for _, tr := range unpredicted(f.Name, data, rule, now) {
+			line += fmt.Sprintf(" %d:%d:%d:%s", tr.at, tr.utoff, tr.isdst, tr.abbr)
+		}
+		lines = append(lines, line)
+	}
+	sort.Strings(lines)
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen_zoneinfo.go; DO NOT EDIT.\n\n")
+	b.WriteString("package ezbar\n\n")
+	b.WriteString("// zoneRules is one \"<IANA name> <POSIX TZ rule>\" per line, sorted by name, with a\n")
+	b.WriteString("// leading newline so every entry is found as \"\\n<name> \". A zone whose rule\n")
+	b.WriteString("// doesn't predict its future adds its transitions from generation time on, each\n")
+	b.WriteString("// \" <unix time>:<utoff>:<isdst>:<abbr>\".\n")
+	b.WriteString("const zoneRules = \"\\n\" +\n")
+	for i, l := range lines {
+		sep := " +\n"
+		if i == len(lines)-1 {
+			sep = "\n"
+		}
+		fmt.Fprintf(&b, "\t%q%s", l+"\n", sep)
+	}
+	if err := os.WriteFile("zoneinfo_table.go", b.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// footer returns the POSIX TZ string a TZif v2+ file ends with ("\n<rule>\n").
+func footer(data []byte) (string, bool) {
+	if len(data) < 5 || string(data[:4]) != "TZif" || data[4] < '2' {
+		return "", false
+	}
+	s := strings.TrimSuffix(string(data), "\n")
+	i := strings.LastIndexByte(s, '\n')
+	if i < 0 || s[i+1:] == "" {
+		return "", false
+	}
+	return s[i+1:], true
+}
+
+// transition is one TZif transition with its zone type resolved.
+type transition struct {
+	at    int64
+	utoff int32
+	isdst byte
+	abbr  string
+}
+
+// unpredicted is name's transitions from the one in effect at now on, if
+// any later one isn't what rule alone gives; otherwise nil.
+func unpredicted(name string, data []byte, rule string, now int64) []transition {
+	trs, ok := transitions(data)
+	if !ok {
+		return nil
+	}
+	full, err := time.LoadLocationFromTZData(name, data)
+	if err != nil {
+		log.Fatalf("%s: %v", name, err)
+	}
+	foot, err := time.LoadLocationFromTZData(name, ruleOnly(rule))
+	if err != nil {
+		log.Fatalf("%s: %v", name, err)
+	}
+	first, differs := 0, false
+	for i, tr := range trs {
+		if tr.at <= now {
+			first = i
+			continue
+		}
+		for _, t := range []int64{tr.at - 1, tr.at} {
+			n1, o1 := time.Unix(t, 0).In(full).Zone()
+			n2, o2 := time.Unix(t, 0).In(foot).Zone()
+			differs = differs || n1 != n2 || o1 != o2
+		}
+	}
+	if !differs {
+		return nil
+	}
+	return trs[first:]
+}
+
+// transitions reads the v2 (64-bit) data block of a TZif file.
+func transitions(data []byte) ([]transition, bool) {
+	const hdrLen = 44
+	counts := func(b []byte) (isut, isstd, leap, time, typ, char int) {
+		n := func(i int) int { return int(binary.BigEndian.Uint32(b[20+4*i:])) }
+		return n(0), n(1), n(2), n(3), n(4), n(5)
+	}
+	if len(data) < hdrLen || data[4] < '2' {
+		return nil, false
+	}
+	isut, isstd, leap, timecnt, typecnt, charcnt := counts(data)
+	v2 := hdrLen + timecnt*5 + typecnt*6 + charcnt + leap*8 + isstd + isut
+	if len(data) < v2+hdrLen {
+		return nil, false
+	}
+	_, _, _, timecnt, typecnt, charcnt = counts(data[v2:])
+	d := data[v2+hdrLen:]
+	if len(d) < timecnt*9+typecnt*6+charcnt {
+		return nil, false
+	}
+	times, idx := d[:timecnt*8], d[timecnt*8:timecnt*9]
+	types := d[timecnt*9 : timecnt*9+typecnt*6]
+	chars := d[timecnt*9+typecnt*6 : timecnt*9+typecnt*6+charcnt]
+	trs := make([]transition, timecnt)
+	for i := range trs {
+		ty := types[6*int(idx[i]):]
+		abbr := chars[ty[5]:]
+		trs[i] = transition{
+			at:    int64(binary.BigEndian.Uint64(times[8*i:])),
+			utoff: int32(binary.BigEndian.Uint32(ty)),
+			isdst: ty[4],
+			abbr:  string(abbr[:bytes.IndexByte(abbr, 0)]),
+		}
+	}
+	return trs, true
+}
+
+// ruleOnly is what ezbar's tzif builds for a zone with no transitions: a TZif
+// file whose footer rule decides every lookup.
+func ruleOnly(rule string) []byte {
+	hdr := func(b []byte, nzone, nchar uint32) []byte {
+		b = append(b, "TZif2"...)
+		b = append(b, make([]byte, 15)...)
+		for _, n := range [6]uint32{0, 0, 0, 0, nzone, nchar} {
+			b = binary.BigEndian.AppendUint32(b, n)
+		}
+		return b
+	}
+	b := hdr(nil, 0, 0)
+	b = hdr(b, 1, 1)
+	b = append(b, 0, 0, 0, 0, 0, 0, 0, '\n')
+	return append(append(b, rule...), '\n')
+}
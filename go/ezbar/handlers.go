@@ -0,0 +1,94 @@
+package ezbar
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PointerHandler handles a pointer event on an [OnClick] area; it returns true
+// if the chip must re-render, as Update does.
+type PointerHandler func(kind PointerKind, delta float32) bool
+
+// handlerPrefix starts every id Handlers assigns; MouseArea ids shouldn't.
+const handlerPrefix = "~"
+
+// Handlers is the table behind [OnClick]: it gives each OnClick area in a
+// tree its id — "~v0", "~v1", … in the chip, "~p0", … in the popup, in tree
+// order — and routes the pointer events that come back on those ids to the
+// area's handler. Each surface's table is rebuilt every time it's bound, so
+// an event always reaches the handler of the tree last handed to the host.
+//
+// [Register] keeps one for the plugin; a tool that drives a plugin outside the
+// host keeps its own, binding every tree it builds and dispatching every
+// event it delivers. The zero value is ready to use.
+type Handlers struct {
+	view, popup []PointerHandler
+}
+
+// View binds the chip tree r and returns it with its OnClick ids filled in.
+func (h *Handlers) View(r Render) Render {
+	h.view = nil
+	return bind(r, handlerPrefix+"v", &h.view)
+}
+
+// Popup binds the popup tree r, as View does the chip.
+func (h *Handlers) Popup(r Render) Render {
+	h.popup = nil
+	return bind(r, handlerPrefix+"p", &h.popup)
+}
+
+// Dispatch runs the handler an EvPointer's id was bound to and returns its
+// answer; ok is false for any other event, which is Update's. An id of the
+// SDK's that's no longer bound (a click that raced a re-render) is dropped:
+// ok, with no re-render.
+func (h *Handlers) Dispatch(ev Event) (redraw, ok bool) {
+	if ev.Kind != EvPointer || !strings.HasPrefix(ev.PointerID, handlerPrefix) {
+		return false, false
+	}
+	id := ev.PointerID[len(handlerPrefix):]
+	var table []PointerHandler
+	switch {
+	case strings.HasPrefix(id, "v"):
+		table = h.view
+	case strings.HasPrefix(id, "p"):
+		table = h.popup
+	}
+	if i, err := strconv.Atoi(id[min(1, len(id)):]); err == nil && i >= 0 && i < len(table) {
+		return table[i](ev.PointerKind, ev.Delta), true
+	}
+	return false, true
+}
+
+// bind assigns ids to r's OnClick areas in pre-order, appending their
+// handlers to table.
+func bind(r Render, prefix string, table *[]PointerHandler) Render {
+	r, _ = rewrite(r, func(n *Render) bool {
+		if n.onPtr == nil {
+			return false
+		}
+		n.hitID = prefix + strconv.Itoa(len(*table))
+		*table = append(*table, n.onPtr)
+		return true
+	})
+	return r
+}
+
+// rewrite applies f to every node of r in pre-order, copying only the nodes
+// on a path to one f changed; f reports whether it changed its node.
+func rewrite(r Render, f func(*Render) bool) (Render, bool) {
+	changed := f(&r)
+	var kids []Render
+	for i, k := range r.kids {
+		b, ok := rewrite(k, f)
+		if ok && kids == nil {
+			kids = append(make([]Render, 0, len(r.kids)), r.kids[:i]...)
+		}
+		if kids != nil {
+			kids = append(kids, b)
+		}
+	}
+	if kids != nil {
+		r.kids, changed = kids, true
+	}
+	return r, changed
+}
@@ -0,0 +1,72 @@
+package ezbar
+
+import "testing"
+
+func TestHandlers(t *testing.T) {
+	var log []string
+	on := func(name string) PointerHandler {
+		return func(k PointerKind, delta float32) bool {
+			log = append(log, name)
+			return k == Press
+		}
+	}
+	shared := Text("shared")
+	tree := Row(
+		OnClick(Text("a"), on("a")),
+		shared,
+		Container(OnClick(Row(OnClick(Text("c"), on("c"))), on("b"))),
+		MouseArea("plain", Text("d")),
+	)
+	var h Handlers
+	got := h.View(tree)
+	want := `row{ mouse-area("~v0"){ text("a",fg) } text("shared",fg) ` +
+		`container{ mouse-area("~v1"){ row{ mouse-area("~v2"){ text("c",fg) } } } } mouse-area("plain"){ text("d",fg) } }`
+	if got.String() != want {
+		t.Errorf("bound = %s\nwant    %s", got, want)
+	}
+	if tree.kids[0].hitID != "" || tree.kids[2].kids[0].hitID != "" {
+		t.Error("binding should leave the plugin's tree as it was")
+	}
+	if err := got.Validate(); err != nil {
+		t.Error(err)
+	}
+	h.Popup(OnClick(Text("p"), on("p")))
+
+	for _, tc := range []struct {
+		ev         Event
+		redraw, ok bool
+		ran        string
+	}{
+		{Event{Kind: EvPointer, PointerID: "~v2", PointerKind: Press}, true, true, "c"},
+		{Event{Kind: EvPointer, PointerID: "~v1", PointerKind: Enter}, false, true, "b"},
+		{Event{Kind: EvPointer, PointerID: "~p0", PointerKind: Press}, true, true, "p"},
+		{Event{Kind: EvPointer, PointerID: "~v3", PointerKind: Press}, false, true, ""}, // stale
+		{Event{Kind: EvPointer, PointerID: "~x", PointerKind: Press}, false, true, ""},
+		{Event{Kind: EvPointer, PointerID: "plain", PointerKind: Press}, false, false, ""},
+		{Event{Kind: EvTimer}, false, false, ""},
+	} {
+		log = nil
+		redraw, ok := h.Dispatch(tc.ev)
+		if ran := ""; len(log) > 0 {
+			ran = log[0]
+			if ran != tc.ran {
+				t.Errorf("%q ran %q, want %q", tc.ev.PointerID, ran, tc.ran)
+			}
+		} else if tc.ran != "" {
+			t.Errorf("%q ran nothing, want %q", tc.ev.PointerID, tc.ran)
+		}
+		if redraw != tc.redraw || ok != tc.ok {
+			t.Errorf("Dispatch(%q) = %v, %v; want %v, %v", tc.ev.PointerID, redraw, ok, tc.redraw, tc.ok)
+		}
+	}
+
+	// a re-bind replaces the table: ~v0 is now the only area.
+	h.View(OnClick(Text("z"), on("z")))
+	log = nil
+	if _, ok := h.Dispatch(Event{Kind: EvPointer, PointerID: "~v0"}); !ok || len(log) != 1 || log[0] != "z" {
+		t.Errorf("after a re-bind ~v0 ran %q", log)
+	}
+	if _, ok := h.Dispatch(Event{Kind: EvPointer, PointerID: "~v1"}); !ok || len(log) != 1 {
+		t.Errorf("after a re-bind ~v1 ran %q, want nothing", log)
+	}
+}
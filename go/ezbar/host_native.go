@@ -0,0 +1,107 @@
+//go:build !wasm
+
+package ezbar
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+)
+
+// Outside wasm there are no host imports to bind: hostCtx forwards every call
+// to an in-process host instead, so a plugin package — Register included —
+// builds and runs under plain `go test`, the race detector and gopls. It
+// carries the whole of CtxV6 whatever the ezbar_wit_v* tag; Ctx still narrows
+// what a plugin can call.
+
+// nativeHost is the in-process host: [UseHost]'s, or a [stubHost].
+var nativeHost CtxV6 = stubHost{}
+
+// UseHost routes the host calls of a native (non-wasm) build to h — a fake
+// such as ezbartest.Ctx. Only native builds have it: in a wasm plugin the host
+// is the bar. UseHost(nil) restores the default, which grants nothing and logs
+// to stderr. [Local] asks the new host for its zone afresh.
+func UseHost(h CtxV6) {
+	if h == nil {
+		h = stubHost{}
+	}
+	nativeHost = h
+	localZone.Once, localZone.loc = sync.Once{}, nil
+}
+
+// UseClock makes the SDK's timer bookkeeping ([Components]) read the time
+// from now — a virtual clock, say, as ezbartest's Driver and Sim keep.
+// UseClock(nil) restores the system clock.
+func UseClock(now func() time.Time) {
+	if now == nil {
+		now = time.Now
+	}
+	clock = now
+}
+
+// SetEm sets the text size behind [Em], as the bar's init and every EvConfig
+// do — for a native tool that delivers those itself, such as ezbartest's
+// Driver. SetEm(0) restores the default, 14.
+func SetEm(px float32) {
+	if px == 0 {
+		px = 14
+	}
+	emPx = px
+}
+
+func (hostCtx) Log(msg string)                     { nativeHost.Log(msg) }
+func (hostCtx) TextSize() float32                  { return nativeHost.TextSize() }
+func (hostCtx) Fg() Color                          { return nativeHost.Fg() }
+func (hostCtx) SetTimeout(ms uint32)               { nativeHost.SetTimeout(ms) }
+func (hostCtx) Subscribe(kinds ...EventKind)       { nativeHost.Subscribe(kinds...) }
+func (hostCtx) HTTPGet(url string) ([]byte, error) { return nativeHost.HTTPGet(url) }
+func (hostCtx) ReadFile(path string) ([]byte, error) {
+	return nativeHost.ReadFile(path)
+}
+func (hostCtx) FeedSubscribe(feed FeedKind, minPeriodMs uint32) {
+	nativeHost.FeedSubscribe(feed, minPeriodMs)
+}
+func (hostCtx) SwaySnapshot() (SwayState, error) { return nativeHost.SwaySnapshot() }
+func (hostCtx) Exec(program string, args []string, stdin []byte) (ExecOutput, error) {
+	return nativeHost.Exec(program, args, stdin)
+}
+func (hostCtx) Pick(prompt string, items []string, current int) (string, bool) {
+	return nativeHost.Pick(prompt, items, current)
+}
+func (hostCtx) LocalTimezone() string                      { return nativeHost.LocalTimezone() }
+func (hostCtx) HTTPOpen(url string) (io.ReadCloser, error) { return nativeHost.HTTPOpen(url) }
+
+// stubHost answers like a bar with an empty [modules.<id>] table: every gated
+// call is denied, timers and subscriptions go nowhere.
+type stubHost struct{}
+
+var errDenied = errors.New(deniedPrefix + ": no native host (see UseHost)")
+
+func (stubHost) Log(msg string)                            { os.Stderr.WriteString(msg + "\n") }
+func (stubHost) TextSize() float32                         { return 14 }
+func (stubHost) Fg() Color                                 { return Fg }
+func (stubHost) SetTimeout(uint32)                         {}
+func (stubHost) After(string, time.Duration)               {}
+func (stubHost) Every(string, time.Duration)               {}
+func (stubHost) Stop(string)                               {}
+func (stubHost) Subscribe(...EventKind)                    {}
+func (stubHost) FeedSubscribe(FeedKind, uint32)            {}
+func (stubHost) HTTPGet(string) ([]byte, error)            { return nil, errDenied }
+func (stubHost) SwaySnapshot() (SwayState, error)          { return SwayState{}, errDenied }
+func (stubHost) Pick(string, []string, int) (string, bool) { return "", false }
+func (stubHost) LocalTimezone() string                     { return "UTC" }
+func (stubHost) HTTPOpen(string) (io.ReadCloser, error)    { return nil, errDenied }
+func (stubHost) ReadFile(path string) ([]byte, error) {
+	return nil, &fs.PathError{Op: "read", Path: path, Err: fs.ErrPermission}
+}
+func (stubHost) Exec(string, []string, []byte) (ExecOutput, error) {
+	return ExecOutput{}, errDenied
+}
+
+// Registered is the Plugin the package passed to [Register], or nil if it
+// hasn't run yet — for native tools such as cmd/ezbar-preview that drive a
+// plugin package through its own registration.
+func Registered() Plugin { return registered }
@@ -0,0 +1,154 @@
+//go:build !wasm
+
+package ezbar
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/birdayz/ezbar/go/internal/ezbar/plugin/events"
+	"github.com/birdayz/ezbar/go/internal/ezbar/plugin/plugin"
+	"go.bytecodealliance.org/cm"
+)
+
+// recHost is a stubHost with a bigger font that records SetTimeout.
+type recHost struct {
+	stubHost
+	timeouts []uint32
+}
+
+func (h *recHost) TextSize() float32    { return 20 }
+func (h *recHost) SetTimeout(ms uint32) { h.timeouts = append(h.timeouts, ms) }
+
+type ticker struct {
+	Base
+	ticks int
+}
+
+func (p *ticker) Update(ctx Ctx, ev Event) bool {
+	p.ticks++
+	ctx.SetTimeout(500)
+	return true
+}
+
+func (p *ticker) View() Render { return Text("tick") }
+
+// TestRegisterNative drives a registered plugin through the component exports,
+// the way the bar does, with UseHost standing in for the bar.
+func TestRegisterNative(t *testing.T) {
+	h := &recHost{}
+	UseHost(h)
+	defer UseHost(nil)
+	defer func(px float32) { emPx = px }(emPx)
+
+	p := &ticker{}
+	Register(p)
+	plugin.Exports.Init(cm.ToList([][2]string{{"k", "v"}}))
+	if got := Em(1); got != 20 {
+		t.Errorf("Em(1) after init = %v, want the host's 20", got)
+	}
+	if !plugin.Exports.Update(events.EventTimer()) {
+		t.Error("Update: want a re-render")
+	}
+	if p.ticks != 1 || len(h.timeouts) != 1 || h.timeouts[0] != 500 {
+		t.Errorf("ticks = %d, timeouts = %v; want 1, [500]", p.ticks, h.timeouts)
+	}
+	if tree := plugin.Exports.View(); tree.Nodes.Len() != 1 {
+		t.Errorf("View: %d nodes, want 1", tree.Nodes.Len())
+	}
+}
+
+// clicker counts presses on an OnClick area, and the events Update sees.
+type clicker struct {
+	Base
+	presses, updates int
+}
+
+func (p *clicker) Update(Ctx, Event) bool { p.updates++; return false }
+
+func (p *clicker) View() Render {
+	return OnClick(Text("click"), func(k PointerKind, _ float32) bool {
+		p.presses++
+		return k == Press
+	})
+}
+
+// TestRegisterOnClick routes a pointer event on the id the View export handed
+// the host to the OnClick handler, past Update.
+func TestRegisterOnClick(t *testing.T) {
+	UseHost(&recHost{})
+	defer UseHost(nil)
+
+	p := &clicker{}
+	Register(p)
+	plugin.Exports.Init(cm.List[[2]string]{})
+	tree := plugin.Exports.View()
+	area := tree.Nodes.Slice()[tree.Root].MouseArea()
+	if area == nil || area.ID != "~v0" {
+		t.Fatalf("View root = %+v, want a mouse-area with id ~v0", tree.Nodes.Slice()[tree.Root])
+	}
+	if !plugin.Exports.Update(events.EventPointer(events.PointerEvent{ID: area.ID, Kind: Press})) {
+		t.Error("a press should re-render, as the handler says")
+	}
+	plugin.Exports.Update(events.EventPointer(events.PointerEvent{ID: "other", Kind: Press}))
+	if p.presses != 1 || p.updates != 1 {
+		t.Errorf("presses = %d, updates = %d; want 1, 1", p.presses, p.updates)
+	}
+}
+
+// namer records the name of each EvTimer.
+type namer struct {
+	Base
+	timers []string
+}
+
+func (p *namer) Update(_ Ctx, ev Event) bool {
+	p.timers = append(p.timers, ev.Timer)
+	return false
+}
+
+func (*namer) View() Render { return Text("") }
+
+// TestHostCtxTimers arms a named timer on a bare hostCtx, not the Ctx Update
+// hands out: it's kept with the plugin's, and fires by name.
+func TestHostCtxTimers(t *testing.T) {
+	h := &recHost{}
+	UseHost(h)
+	defer UseHost(nil)
+	at := time.Unix(0, 0)
+	UseClock(func() time.Time { return at })
+	defer UseClock(nil)
+
+	p := &namer{}
+	Register(p)
+	hostCtx{}.After("x", 2*time.Second)
+	at = at.Add(2 * time.Second)
+	plugin.Exports.Update(events.EventTimer())
+	if !reflect.DeepEqual(h.timeouts, []uint32{2000}) || !reflect.DeepEqual(p.timers, []string{"x"}) {
+		t.Errorf("timeouts = %v, timers = %q; want [2000], [x]", h.timeouts, p.timers)
+	}
+}
+
+// oddFg is a stubHost whose text colour is a theme token this SDK has no name
+// for, as a newer host's could be.
+type oddFg struct{ stubHost }
+
+func (oddFg) Fg() Color { return Color{tok: 9} }
+
+// TestRecordFg records every Fg answer, so a replay stays in step.
+func TestRecordFg(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace")
+	r := recorder{Ctx: oddFg{}, t: &trace{path: path}}
+	r.Fg()
+	r.t.f.Close()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != "fg token 9\n" {
+		t.Errorf("trace = %q, want one fg record", got)
+	}
+}
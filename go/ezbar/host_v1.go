@@ -0,0 +1,16 @@
+//go:build wasm && ezbar_wit_v1
+
+package ezbar
+
+import "github.com/birdayz/ezbar/go/internal/ezbar/plugin/v0.1.0/host"
+
+func (hostCtx) Log(msg string)               { host.Log(msg) }
+func (hostCtx) TextSize() float32            { return host.TextSize() }
+func (hostCtx) Fg() Color                    { return colorOf(host.Fg()) }
+func (hostCtx) SetTimeout(ms uint32)         { host.SetTimeout(ms) }
+func (hostCtx) Subscribe(kinds ...EventKind) { host.Subscribe(subscribeKinds(kinds)) }
+func (hostCtx) FeedSubscribe(feed FeedKind, minPeriodMs uint32) {
+	host.FeedSubscribe(feed, minPeriodMs)
+}
+func (hostCtx) HTTPGet(url string) ([]byte, error)   { return bytesOf(unwrap(host.HTTPGet(url))) }
+func (hostCtx) ReadFile(path string) ([]byte, error) { return readFile(path, host.ReadFile(path)) }
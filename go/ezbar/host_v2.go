@@ -0,0 +1,25 @@
+//go:build wasm && ezbar_wit_v2
+
+package ezbar
+
+import "github.com/birdayz/ezbar/go/internal/ezbar/plugin/v0.2.0/host"
+
+func (hostCtx) Log(msg string)               { host.Log(msg) }
+func (hostCtx) TextSize() float32            { return host.TextSize() }
+func (hostCtx) Fg() Color                    { return colorOf(host.Fg()) }
+func (hostCtx) SetTimeout(ms uint32)         { host.SetTimeout(ms) }
+func (hostCtx) Subscribe(kinds ...EventKind) { host.Subscribe(subscribeKinds(kinds)) }
+func (hostCtx) FeedSubscribe(feed FeedKind, minPeriodMs uint32) {
+	host.FeedSubscribe(feed, minPeriodMs)
+}
+func (hostCtx) HTTPGet(url string) ([]byte, error)   { return bytesOf(unwrap(host.HTTPGet(url))) }
+func (hostCtx) ReadFile(path string) ([]byte, error) { return readFile(path, host.ReadFile(path)) }
+
+func (hostCtx) SwaySnapshot() (SwayState, error) {
+	s, err := unwrap(host.SwaySnapshot())
+	ws := make([]SwayWorkspace, 0, s.Workspaces.Len())
+	for _, w := range s.Workspaces.Slice() {
+		ws = append(ws, SwayWorkspace{Name: w.Name, Focused: w.Focused, Visible: w.Visible, Urgent: w.Urgent})
+	}
+	return SwayState{Workspaces: ws, Title: s.Title}, err
+}
@@ -0,0 +1,36 @@
+//go:build wasm && ezbar_wit_v4
+
+package ezbar
+
+import "github.com/birdayz/ezbar/go/internal/ezbar/plugin/v0.4.0/host"
+
+func (hostCtx) Log(msg string)               { host.Log(msg) }
+func (hostCtx) TextSize() float32            { return host.TextSize() }
+func (hostCtx) Fg() Color                    { return colorOf(host.Fg()) }
+func (hostCtx) SetTimeout(ms uint32)         { host.SetTimeout(ms) }
+func (hostCtx) Subscribe(kinds ...EventKind) { host.Subscribe(subscribeKinds(kinds)) }
+func (hostCtx) FeedSubscribe(feed FeedKind, minPeriodMs uint32) {
+	host.FeedSubscribe(feed, minPeriodMs)
+}
+func (hostCtx) HTTPGet(url string) ([]byte, error)   { return bytesOf(unwrap(host.HTTPGet(url))) }
+func (hostCtx) ReadFile(path string) ([]byte, error) { return readFile(path, host.ReadFile(path)) }
+
+func (hostCtx) SwaySnapshot() (SwayState, error) {
+	s, err := unwrap(host.SwaySnapshot())
+	ws := make([]SwayWorkspace, 0, s.Workspaces.Len())
+	for _, w := range s.Workspaces.Slice() {
+		ws = append(ws, SwayWorkspace{Name: w.Name, Focused: w.Focused, Visible: w.Visible, Urgent: w.Urgent})
+	}
+	return SwayState{Workspaces: ws, Title: s.Title}, err
+}
+
+func (hostCtx) Exec(program string, args []string, stdin []byte) (ExecOutput, error) {
+	list, in := execArgs(args, stdin)
+	o, err := unwrap(host.Exec(program, list, in))
+	return execOutput(o.Code, o.Stdout, o.Stderr), err
+}
+
+func (hostCtx) Pick(prompt string, items []string, current int) (string, bool) {
+	list, cur := pickArgs(items, current)
+	return picked(host.Pick(prompt, list, cur))
+}
@@ -0,0 +1,71 @@
+package ezbar
+
+// NodeKind is which widget a [Render] node is.
+type NodeKind uint8
+
+// In renderKind order.
+const (
+	NodeSpacer NodeKind = iota
+	NodeText
+	NodeRow
+	NodeColumn
+	NodeContainer
+	NodeMouseArea
+	NodeIcon
+	NodeGraph
+	NodeChart
+)
+
+// Node is a read-only view of one Render node, for tools that draw or check a
+// tree outside the host — the terminal preview, the image renderer. Only the
+// fields of its Kind are set.
+type Node struct {
+	Kind NodeKind
+
+	Text    string  // text content
+	Color   Color   // text/icon colour, graph/chart line colour
+	Size    float32 // text size if HasSize (else the bar's), icon size
+	HasSize bool
+
+	Children []Render // row/column children; the one child of a container/mouse-area
+	Spacing  float32  // row/column
+	Align    Align    // row/column cross-axis
+	Padding  float32  // container
+	ID       string   // mouse-area
+
+	Icon      Icon
+	GraphKind GraphKind
+	Values    []float64 // graph/chart data
+	Width     float32   // chart width, spacer px
+	Height    float32   // chart height
+}
+
+// Node opens r up for reading.
+func (r Render) Node() Node {
+	n := Node{Kind: NodeKind(r.kind), Children: r.kids}
+	switch r.kind {
+	case kText:
+		n.Text, n.Color, n.Size, n.HasSize = r.text, r.color, r.size, r.hasSize
+	case kRow, kColumn:
+		n.Spacing, n.Align = r.spacing, r.align
+	case kContainer:
+		n.Padding = r.padding
+	case kMouseArea:
+		n.ID = r.hitID
+	case kIcon:
+		n.Icon, n.Size, n.Color = r.icon, r.isize, r.color
+	case kGraph:
+		n.Values, n.GraphKind, n.Color = r.values, r.gkind, r.color
+	case kChart:
+		n.Values, n.Color, n.Width, n.Height = r.values, r.color, r.width, r.height
+	default:
+		n.Width = r.width
+	}
+	return n
+}
+
+// RGBA is c's literal colour; ok is false for a theme token, which only the
+// host (or a tool standing in for it) can resolve.
+func (c Color) RGBA() (r, g, b, a uint8, ok bool) {
+	return c.rgba.R, c.rgba.G, c.rgba.B, c.rgba.A, c.isRGBA
+}
@@ -0,0 +1,28 @@
+package ezbar
+
+import "testing"
+
+func TestNode(t *testing.T) {
+	r := MouseArea("chip", Row(Text("hi").Size(18), IconSun.View(14, RGBA(1, 2, 3, 4))).Spacing(5))
+	n := r.Node()
+	if n.Kind != NodeMouseArea || n.ID != "chip" || len(n.Children) != 1 {
+		t.Fatalf("mouse-area: %+v", n)
+	}
+	row := n.Children[0].Node()
+	if row.Kind != NodeRow || row.Spacing != 5 || row.Align != AlignCenter || len(row.Children) != 2 {
+		t.Fatalf("row: %+v", row)
+	}
+	if txt := row.Children[0].Node(); txt.Text != "hi" || !txt.HasSize || txt.Size != 18 || txt.Color != Fg {
+		t.Errorf("text: %+v", txt)
+	}
+	icon := row.Children[1].Node()
+	if r, g, b, a, ok := icon.Color.RGBA(); icon.Icon != IconSun || icon.Size != 14 || !ok || r != 1 || g != 2 || b != 3 || a != 4 {
+		t.Errorf("icon: %+v", icon)
+	}
+	if _, _, _, _, ok := Accent.RGBA(); ok {
+		t.Error("a token has no literal RGBA")
+	}
+	if sp := Spacer(3).Node(); sp.Kind != NodeSpacer || sp.Width != 3 {
+		t.Errorf("spacer: %+v", sp)
+	}
+}
@@ -0,0 +1,319 @@
+//go:build !wasm
+
+// Package preview runs a registered plugin natively on the simulated host
+// ([ezbartest.Sim], on the wall clock) and draws its chip and popup in a
+// terminal: theme tokens as 24-bit colours, a Graph as a sparkline, a Chart as
+// block characters. Keys inject pointer events by mouse-area id.
+//
+// It is the runtime half of cmd/ezbar-preview, which builds the plugin package
+// with one extra file that calls [Main] from init, after the package's own
+// Register.
+package preview
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+	"github.com/birdayz/ezbar/go/ezbar/ezbartest"
+)
+
+// Env carries the launcher's [Options], as JSON.
+const Env = "EZBAR_PREVIEW"
+
+// Options configure a preview.
+type Options struct {
+	Config map[string]string // the [modules.<id>] table passed to Load
+	Feeds  []string          // feeds to grant, by name ("cpu", …); each gets a synthetic series
+	Popup  bool              // show the popup from the start
+	Color  bool              // 24-bit ANSI colour
+	For    time.Duration     // if set, run this long on the virtual clock, print one frame and return
+}
+
+// frameEvery is the wall-clock step the interactive loop advances the
+// simulation by.
+const frameEvery = 50 * time.Millisecond
+
+// Main previews the registered plugin with the Options in $EZBAR_PREVIEW, then
+// exits the process.
+func Main() {
+	var o Options
+	if env := os.Getenv(Env); env != "" {
+		if err := json.Unmarshal([]byte(env), &o); err != nil {
+			fmt.Fprintln(os.Stderr, "ezbar-preview: bad "+Env+":", err)
+			os.Exit(2)
+		}
+	}
+	p := ezbar.Registered()
+	if p == nil {
+		fmt.Fprintln(os.Stderr, "ezbar-preview: the package registered no plugin (call ezbar.Register from init)")
+		os.Exit(1)
+	}
+	if err := Run(p, o, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "ezbar-preview:", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// Run previews p: interactively on the terminal in, or — with o.For set — as
+// one frame written to out.
+func Run(p ezbar.Plugin, o Options, in *os.File, out io.Writer) error {
+	v, err := newViewer(p, o)
+	if err != nil {
+		return err
+	}
+	defer v.t.cleanup()
+
+	if o.For > 0 {
+		v.sim.Run(o.For)
+		_, err := io.WriteString(out, v.frame())
+		return err
+	}
+	if fi, err := in.Stat(); err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+		return errors.New("stdin is not a terminal (use -for to print a single frame)")
+	}
+	restore, err := rawMode(in)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	io.WriteString(out, "\x1b[?1049h\x1b[?25l") // alternate screen, hidden cursor
+	defer io.WriteString(out, "\x1b[?25h\x1b[?1049l")
+
+	keys := make(chan []byte)
+	go func() {
+		buf := make([]byte, 16)
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				close(keys)
+				return
+			}
+			keys <- append([]byte(nil), buf[:n]...)
+		}
+	}()
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	tick := time.NewTicker(frameEvery)
+	defer tick.Stop()
+	last, shown := time.Now(), ""
+	for {
+		if f := v.frame(); f != shown {
+			io.WriteString(out, "\x1b[H\x1b[2J"+strings.ReplaceAll(f, "\n", "\r\n"))
+			shown = f
+		}
+		select {
+		case now := <-tick.C:
+			v.sim.Run(now.Sub(last))
+			last = now
+		case k, ok := <-keys:
+			if !ok || !v.key(k) {
+				return nil
+			}
+			v.sim.Run(0) // deliver it now, unless the host would still hold it
+		case <-sigs:
+			return nil
+		}
+	}
+}
+
+// viewer is the state of one preview.
+type viewer struct {
+	opts     Options
+	sim      *ezbartest.Sim
+	t        *tb
+	popup    bool
+	areas    []string // mouse-area ids of the last frame, chip then popup
+	selected int
+	last     string // the last injected event, for the status line
+}
+
+// newViewer loads p on a fresh simulator with o's config and feeds.
+func newViewer(p ezbar.Plugin, o Options) (*viewer, error) {
+	v := &viewer{opts: o, popup: o.Popup, t: &tb{}}
+	v.sim = ezbartest.NewSim(v.t, p)
+	v.sim.Series = map[ezbar.FeedKind]ezbartest.Series{}
+	for _, name := range o.Feeds {
+		kind, ok := feedKind(name)
+		if !ok {
+			return nil, errors.New("unknown feed " + strconv.Quote(name))
+		}
+		v.sim.Series[kind] = synthetic(kind)
+	}
+	v.sim.Init(o.Config)
+	if !v.sim.Steps[0].Redraw {
+		v.sim.Last = v.sim.View() // the host paints a first frame regardless
+	}
+	return v, nil
+}
+
+// key handles one keypress; false quits.
+func (v *viewer) key(k []byte) bool {
+	switch s := string(k); {
+	case s == "q" || s == "\x03":
+		return false
+	case s == "p":
+		v.popup = !v.popup
+	case s == "\t":
+		if len(v.areas) > 0 {
+			v.selected = (v.selected + 1) % len(v.areas)
+		}
+	case len(s) == 1 && s[0] >= '1' && s[0] <= '9':
+		if i := int(s[0] - '1'); i < len(v.areas) {
+			v.selected = i
+			v.pointer(ezbar.Press, 0)
+		}
+	case s == "\r" || s == " ":
+		v.pointer(ezbar.Press, 0)
+	case s == "r":
+		v.pointer(ezbar.RightPress, 0)
+	case s == "k" || s == "\x1b[A":
+		v.pointer(ezbar.Scroll, 1)
+	case s == "j" || s == "\x1b[B":
+		v.pointer(ezbar.Scroll, -1)
+	case s == "e":
+		v.pointer(ezbar.Enter, 0)
+	case s == "l":
+		v.pointer(ezbar.Leave, 0)
+	}
+	return true
+}
+
+var pointerNames = map[ezbar.PointerKind]string{
+	ezbar.Press: "press", ezbar.RightPress: "right-press", ezbar.Scroll: "scroll", ezbar.Enter: "enter", ezbar.Leave: "leave",
+}
+
+func (v *viewer) pointer(kind ezbar.PointerKind, delta float32) {
+	if v.selected >= len(v.areas) {
+		return
+	}
+	id := v.areas[v.selected]
+	v.sim.PointerAt(v.sim.Now, id, kind, delta)
+	v.last = pointerNames[kind] + " " + strconv.Quote(id)
+	if kind == ezbar.Scroll {
+		v.last += " " + strconv.FormatFloat(float64(delta), 'g', -1, 32)
+	}
+}
+
+// frame draws the chip, the popup if it's shown, the mouse areas and the
+// status lines.
+func (v *viewer) frame() string {
+	var sb strings.Builder
+	sb.WriteString("ezbar-preview  t=" + v.sim.Now.Round(100*time.Millisecond).String() + "\n\n")
+	l := &layout{}
+	if v.selected < len(v.areas) {
+		l.marked = v.areas[v.selected]
+	}
+	paint(&sb, l.node(v.sim.Last, 0), v.opts.Color)
+	if v.popup {
+		sb.WriteString("\npopup:\n")
+		if tree, ok := v.sim.Popup(); ok {
+			paint(&sb, l.node(tree, 0), v.opts.Color)
+		} else {
+			sb.WriteString("  (none)\n")
+		}
+	}
+	v.areas = l.areas
+	v.selected = min(v.selected, max(len(v.areas)-1, 0))
+
+	sb.WriteString("\nmouse areas:")
+	if len(v.areas) == 0 {
+		sb.WriteString(" none")
+	}
+	for i, id := range v.areas {
+		mark := " "
+		if i == v.selected {
+			mark = "*"
+		}
+		sb.WriteString(" " + mark + "[" + strconv.Itoa(i+1) + "] " + id)
+	}
+	sb.WriteString("\nkeys: 1-9 press · tab select · enter press · r right-press · ↑/↓ scroll · e/l enter/leave · p popup · q quit\n")
+	if v.last != "" {
+		sb.WriteString("sent: " + v.last + "\n")
+	}
+	logs := append(append([]string(nil), v.sim.Ctx.Logs...), v.t.lines...)
+	for _, line := range logs[max(len(logs)-5, 0):] {
+		sb.WriteString("log: " + line + "\n")
+	}
+	return sb.String()
+}
+
+// rawMode turns off line buffering and echo on the terminal in, via stty so
+// it needs no dependency; the returned func restores it.
+func rawMode(in *os.File) (func(), error) {
+	stty := func(args ...string) (string, error) {
+		cmd := exec.Command("stty", args...)
+		cmd.Stdin = in
+		out, err := cmd.Output()
+		return strings.TrimSpace(string(out)), err
+	}
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("stty: %w", err)
+	}
+	if _, err := stty("-icanon", "-echo", "min", "1"); err != nil {
+		return nil, fmt.Errorf("stty: %w", err)
+	}
+	return func() { stty(saved) }, nil
+}
+
+func feedKind(name string) (ezbar.FeedKind, bool) {
+	for _, k := range []ezbar.FeedKind{ezbar.FeedCPU, ezbar.FeedMemory, ezbar.FeedTemperature, ezbar.FeedPing, ezbar.FeedBattery, ezbar.FeedNet} {
+		if k.String() == name {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+// synthetic is a plausible, slowly swelling series for a granted feed.
+func synthetic(kind ezbar.FeedKind) ezbartest.Series {
+	mid, amp := 50.0, 35.0 // percent
+	switch kind {
+	case ezbar.FeedTemperature:
+		mid, amp = 60, 12 // °C
+	case ezbar.FeedPing:
+		mid, amp = 40, 25 // ms
+	case ezbar.FeedBattery:
+		mid, amp = 70, 25
+	case ezbar.FeedNet:
+		mid, amp = 2e6, 1.5e6 // bytes/s
+	}
+	return func(at time.Duration) (float64, bool) {
+		s := at.Seconds()
+		return mid + amp*(0.8*math.Sin(s/9)+0.2*math.Sin(s*1.7)), true
+	}
+}
+
+// tb lets the preview run the test package's simulator outside a test, as
+// its [ezbartest.TB]: failures and logs become status lines instead of
+// failing a test.
+type tb struct {
+	lines    []string
+	cleanups []func()
+}
+
+func (t *tb) Helper()          {}
+func (t *tb) Cleanup(f func()) { t.cleanups = append(t.cleanups, f) }
+func (t *tb) Logf(format string, args ...any) {
+	t.lines = append(t.lines, fmt.Sprintf(format, args...))
+}
+func (t *tb) Errorf(format string, args ...any) { t.Logf("error: "+format, args...) }
+func (t *tb) cleanup() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
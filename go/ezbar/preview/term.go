@@ -0,0 +1,312 @@
+//go:build !wasm
+
+package preview
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+	"github.com/birdayz/ezbar/go/ezbar/snapshot"
+)
+
+// A terminal cell stands in for this many px of the host's layout: ~0.6em
+// advance and a ~1.45em line box at the default 14px text size, the same
+// estimates the host's popup `measure` uses.
+const (
+	cellW = 8.4
+	cellH = 20.0
+)
+
+// sparkWidth is how many of a Graph's newest samples the sparkline shows, one
+// per cell.
+const sparkWidth = 16
+
+type rgb struct{ r, g, b uint8 }
+
+// theme is the bar's defaults, [snapshot.DefaultTheme].
+var theme = func() map[ezbar.Color]rgb {
+	m := map[ezbar.Color]rgb{}
+	for _, tok := range []ezbar.Color{ezbar.Fg, ezbar.FgDim, ezbar.Accent, ezbar.OK, ezbar.Warn, ezbar.Urgent, ezbar.Bg} {
+		c := snapshot.DefaultTheme.Color(tok)
+		m[tok] = rgb{c.R, c.G, c.B}
+	}
+	return m
+}()
+
+// resolve is c under the default theme; an RGBA colour is blended onto the
+// bar background by its alpha.
+func resolve(c ezbar.Color) rgb {
+	r, g, b, a, ok := c.RGBA()
+	if !ok {
+		return theme[c]
+	}
+	bg := theme[ezbar.Bg]
+	mix := func(v, under uint8) uint8 { return uint8((int(v)*int(a) + int(under)*(255-int(a))) / 255) }
+	return rgb{mix(r, bg.r), mix(g, bg.g), mix(b, bg.b)}
+}
+
+// glyphs draws each icon as one narrow cell.
+var glyphs = [...]rune{
+	ezbar.IconCPU: '▣', ezbar.IconMemory: '▤', ezbar.IconTemperature: '°', ezbar.IconPing: '⇄',
+	ezbar.IconVolumeHigh: '♪', ezbar.IconVolumeMedium: '♪', ezbar.IconVolumeMute: '×',
+	ezbar.IconBattery: '▭', ezbar.IconBatteryCharging: '↯', ezbar.IconBatteryWarning: '!',
+	ezbar.IconBot: '☺', ezbar.IconGithub: '⌥', ezbar.IconSpotify: '♫', ezbar.IconKubernetes: '⎈',
+	ezbar.IconClock: '◷', ezbar.IconCalendar: '▦', ezbar.IconDisk: '◍', ezbar.IconNet: '⇅',
+	ezbar.IconIP: '@', ezbar.IconUpdates: '↻', ezbar.IconKeyboard: '⌨', ezbar.IconCloud: '☁',
+	ezbar.IconSun: '☀', ezbar.IconMoon: '☾', ezbar.IconAlert: '⚠', ezbar.IconDot: '•',
+	ezbar.IconCloudSun: '☁', ezbar.IconCloudMoon: '☁', ezbar.IconCloudFog: '▒',
+	ezbar.IconCloudDrizzle: '⁖', ezbar.IconCloudRain: '☂', ezbar.IconCloudRainWind: '☂',
+	ezbar.IconCloudSnow: '❅', ezbar.IconCloudHail: '⁂', ezbar.IconCloudLightning: 'ϟ',
+	ezbar.IconDroplets: '≈', ezbar.IconWind: '≋', ezbar.IconSunrise: '◒', ezbar.IconSunset: '◓',
+	ezbar.IconSnowflake: '❄',
+}
+
+type cell struct {
+	ch   rune
+	fg   rgb
+	mark bool // inside the selected mouse area
+}
+
+// block is a laid-out subtree: h rows of w cells.
+type block struct {
+	w, h  int
+	cells [][]cell
+}
+
+func newBlock(w, h int) block {
+	b := block{w: w, h: h, cells: make([][]cell, h)}
+	for y := range b.cells {
+		b.cells[y] = make([]cell, w)
+		for x := range b.cells[y] {
+			b.cells[y][x].ch = ' '
+		}
+	}
+	return b
+}
+
+func (b block) blit(src block, x, y int) {
+	for sy, row := range src.cells {
+		copy(b.cells[y+sy][x:], row)
+	}
+}
+
+// layout lays out a Render tree in cells, as the host would in px: rows and
+// columns with their spacing and cross-axis align, padded containers, spacers
+// as blank width. It collects the mouse-area ids in tree order, and flags the
+// cells of the marked one for highlighting.
+type layout struct {
+	areas  []string
+	marked string
+}
+
+func cells(px float32, per float64) int { return int(math.Round(float64(px) / per)) }
+
+func (l *layout) node(r ezbar.Render, depth int) block {
+	if depth > ezbar.MaxDepth {
+		return text("…", theme[ezbar.Fg])
+	}
+	n := r.Node()
+	switch n.Kind {
+	case ezbar.NodeText:
+		return text(n.Text, resolve(n.Color))
+	case ezbar.NodeRow, ezbar.NodeColumn:
+		return l.stack(n, depth)
+	case ezbar.NodeContainer:
+		kid := l.node(n.Children[0], depth+1)
+		px, py := cells(n.Padding, cellW), cells(n.Padding, cellH)
+		b := newBlock(kid.w+2*px, kid.h+2*py)
+		b.blit(kid, px, py)
+		return b
+	case ezbar.NodeMouseArea:
+		l.areas = append(l.areas, n.ID)
+		kid := l.node(n.Children[0], depth+1)
+		if n.ID == l.marked {
+			for _, row := range kid.cells {
+				for x := range row {
+					row[x].mark = true
+				}
+			}
+		}
+		return kid
+	case ezbar.NodeIcon:
+		ch := '?'
+		if int(n.Icon) < len(glyphs) {
+			ch = glyphs[n.Icon]
+		}
+		b := newBlock(1, 1)
+		b.cells[0][0] = cell{ch: ch, fg: resolve(n.Color)}
+		return b
+	case ezbar.NodeGraph:
+		return sparkline(n.Values, n.GraphKind, resolve(n.Color))
+	case ezbar.NodeChart:
+		return chart(n.Values, max(cells(n.Width, cellW), 1), max(cells(n.Height, cellH), 1), resolve(n.Color))
+	default:
+		return newBlock(cells(n.Width, cellW), 0)
+	}
+}
+
+func (l *layout) stack(n ezbar.Node, depth int) block {
+	kids := make([]block, len(n.Children))
+	for i, c := range n.Children {
+		kids[i] = l.node(c, depth+1)
+	}
+	across := n.Kind == ezbar.NodeRow
+	gap := cells(n.Spacing, cellH)
+	if across {
+		gap = cells(n.Spacing, cellW)
+	}
+	main, cross := 0, 0
+	for i, k := range kids {
+		if i > 0 {
+			main += gap
+		}
+		if across {
+			main, cross = main+k.w, max(cross, k.h)
+		} else {
+			main, cross = main+k.h, max(cross, k.w)
+		}
+	}
+	// offset places a child along the cross axis.
+	offset := func(size int) int {
+		switch n.Align {
+		case ezbar.AlignCenter:
+			return (cross - size) / 2
+		case ezbar.AlignEnd:
+			return cross - size
+		}
+		return 0
+	}
+	if across {
+		b := newBlock(main, cross)
+		x := 0
+		for _, k := range kids {
+			b.blit(k, x, offset(k.h))
+			x += k.w + gap
+		}
+		return b
+	}
+	b := newBlock(cross, main)
+	y := 0
+	for _, k := range kids {
+		b.blit(k, offset(k.w), y)
+		y += k.h + gap
+	}
+	return b
+}
+
+func text(s string, fg rgb) block {
+	rs := []rune(s)
+	b := newBlock(len(rs), 1)
+	for x, r := range rs {
+		b.cells[0][x] = cell{ch: r, fg: fg}
+	}
+	return b
+}
+
+var bars = []rune("▁▂▃▄▅▆▇█")
+
+// yrange is the host's y-range for a graph kind: Cpu/Memory are fixed
+// 0..100, Ping is 0..max(100, peak), the rest auto-fit (a flat series gets a
+// unit range).
+func yrange(vs []float64, kind ezbar.GraphKind) (lo, hi float64) {
+	switch kind {
+	case ezbar.GraphCPU, ezbar.GraphMemory:
+		return 0, 100
+	}
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, v := range vs {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	if kind == ezbar.GraphPing {
+		return 0, max(hi, 100)
+	}
+	if hi-lo < 1e-9 {
+		hi = lo + 1
+	}
+	return lo, hi
+}
+
+// level maps v into 0..steps-1 over lo..hi.
+func level(v, lo, hi float64, steps int) int {
+	f := (v - lo) / (hi - lo)
+	return min(max(int(math.Round(f*float64(steps-1))), 0), steps-1)
+}
+
+func sparkline(vs []float64, kind ezbar.GraphKind, fg rgb) block {
+	if len(vs) > sparkWidth {
+		vs = vs[len(vs)-sparkWidth:]
+	}
+	b := newBlock(sparkWidth, 1)
+	if len(vs) == 0 {
+		return b
+	}
+	lo, hi := yrange(vs, kind)
+	off := sparkWidth - len(vs) // right-aligned: newest sample at the edge
+	for i, v := range vs {
+		b.cells[0][off+i] = cell{ch: bars[level(v, lo, hi, len(bars))], fg: fg}
+	}
+	return b
+}
+
+// chart is a w×h block-character area chart of vs resampled to w columns,
+// auto-fit like the host's MiniTrend.
+func chart(vs []float64, w, h int, fg rgb) block {
+	b := newBlock(w, h)
+	if len(vs) == 0 {
+		return b
+	}
+	lo, hi := yrange(vs, ezbar.GraphGeneric)
+	steps := h * len(bars)
+	for x := range w {
+		i := len(vs) - 1
+		if w > 1 {
+			i = int(math.Round(float64(x) * float64(len(vs)-1) / float64(w-1)))
+		}
+		fill := level(vs[i], lo, hi, steps) + 1 // eighths of a cell, bottom up
+		for y := h - 1; y >= 0 && fill > 0; y-- {
+			n := min(fill, len(bars))
+			b.cells[y][x] = cell{ch: bars[n-1], fg: fg}
+			fill -= n
+		}
+	}
+	return b
+}
+
+// paint writes b out, one line per row: with 24-bit ANSI colours on the bar
+// background when color is set, as plain text otherwise. Marked cells are
+// underlined in colour; in plain text only the legend shows the selection.
+func paint(sb *strings.Builder, b block, color bool) {
+	bg := theme[ezbar.Bg]
+	for _, row := range b.cells {
+		if !color {
+			for _, c := range row {
+				sb.WriteRune(c.ch)
+			}
+			sb.WriteByte('\n')
+			continue
+		}
+		sb.WriteString(sgr(48, bg))
+		var cur *cell
+		for i := range row {
+			c := &row[i]
+			if cur == nil || c.fg != cur.fg || c.mark != cur.mark {
+				if c.mark {
+					sb.WriteString("\x1b[4m")
+				} else {
+					sb.WriteString("\x1b[24m")
+				}
+				sb.WriteString(sgr(38, c.fg))
+				cur = c
+			}
+			sb.WriteRune(c.ch)
+		}
+		sb.WriteString("\x1b[0m\n")
+	}
+}
+
+// sgr is a 24-bit colour escape; layer 38 is foreground, 48 background.
+func sgr(layer int, c rgb) string {
+	return "\x1b[" + strconv.Itoa(layer) + ";2;" + strconv.Itoa(int(c.r)) + ";" + strconv.Itoa(int(c.g)) + ";" + strconv.Itoa(int(c.b)) + "m"
+}
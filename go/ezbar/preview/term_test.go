@@ -0,0 +1,102 @@
+//go:build !wasm
+
+package preview
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+	"github.com/birdayz/ezbar/go/ezbar/ezbartest"
+)
+
+func draw(l *layout, r ezbar.Render) string {
+	var sb strings.Builder
+	paint(&sb, l.node(r, 0), false)
+	return sb.String()
+}
+
+func TestLayout(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		r    ezbar.Render
+		want string
+	}{
+		{"row", ezbar.Row(ezbar.IconCPU.View(14, ezbar.Accent), ezbar.Text("42%")).Spacing(8.4), "▣ 42%\n"},
+		{"column align end", ezbar.Column(ezbar.Text("abc"), ezbar.Text("d")).Align(ezbar.AlignEnd), "abc\n  d\n"},
+		{"row center", ezbar.Row(ezbar.Column(ezbar.Text("a"), ezbar.Text("b"), ezbar.Text("c")), ezbar.Text("x")), "a \nbx\nc \n"},
+		{"padding", ezbar.Container(ezbar.Text("x")).Padding(20), "     \n  x  \n     \n"},
+		{"spacer", ezbar.Row(ezbar.Text("a"), ezbar.Spacer(16.8), ezbar.Text("b")), "a  b\n"},
+		{"sparkline", ezbar.Graph{Values: []float64{0, 50, 100}, Kind: ezbar.GraphCPU}.View(), strings.Repeat(" ", 13) + "▁▅█\n"},
+		{"chart", ezbar.Chart{Values: []float64{0, 1}, Width: 16.8, Height: 40}.View(), " █\n▁█\n"},
+	} {
+		if got := draw(&layout{}, tc.r); got != tc.want {
+			t.Errorf("%s:\n%q\nwant\n%q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestAreas(t *testing.T) {
+	r := ezbar.Row(
+		ezbar.MouseArea("a", ezbar.Text("x")),
+		ezbar.MouseArea("b", ezbar.Text("y")),
+	)
+	l := &layout{marked: "b"}
+	b := l.node(r, 0)
+	if strings.Join(l.areas, ",") != "a,b" {
+		t.Errorf("areas %v, want [a b]", l.areas)
+	}
+	if b.cells[0][0].mark || !b.cells[0][1].mark {
+		t.Errorf("only b's cell should be marked: %+v", b.cells[0])
+	}
+	var sb strings.Builder
+	paint(&sb, b, true)
+	if got := sb.String(); !strings.Contains(got, "\x1b[4m\x1b[38;2;255;255;255my") {
+		t.Errorf("marked cell not underlined: %q", got)
+	}
+}
+
+// clicker counts presses on its chip.
+type clicker struct {
+	ezbar.Base
+	n int
+}
+
+func (c *clicker) Update(ctx ezbar.Ctx, ev ezbar.Event) bool {
+	if ev.Kind == ezbar.EvPointer && ev.PointerID == "chip" && ev.PointerKind == ezbar.Press {
+		c.n++
+		return true
+	}
+	return false
+}
+
+func (c *clicker) View() ezbar.Render {
+	return ezbar.MouseArea("chip", ezbar.Text(strings.Repeat("|", c.n)))
+}
+
+func TestKeys(t *testing.T) {
+	v, err := newViewer(&clicker{}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.t.cleanup()
+	v.sim.Run(time.Second)
+	v.frame() // collects the mouse areas
+	for _, k := range []string{"1", "\r", " "} {
+		if !v.key([]byte(k)) {
+			t.Fatalf("key %q quit", k)
+		}
+		v.sim.Run(ezbartest.MinInterval)
+	}
+	f := v.frame()
+	if !strings.Contains(f, "\n|||\n") || !strings.Contains(f, `sent: press "chip"`) {
+		t.Errorf("after three presses:\n%s", f)
+	}
+	if v.key([]byte("q")) {
+		t.Error("q didn't quit")
+	}
+	if _, err := newViewer(&clicker{}, Options{Feeds: []string{"gpu"}}); err == nil {
+		t.Error("unknown feed accepted")
+	}
+}
@@ -0,0 +1,127 @@
+package ezbar
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/birdayz/ezbar/go/internal/ezbar/plugin/types"
+	"github.com/birdayz/ezbar/go/internal/ezbar/plugin/ui"
+)
+
+// raise is the inverse of lower: it rebuilds the Render a tree encodes, under
+// the host's lift rules (crates/ezbar-wasm lift/check_fwd). It rejects what
+// the host rejects — more than MaxNodes nodes, a child index that doesn't
+// point backwards, a root out of range — and cuts a mouse-area id to
+// MaxIDLen characters as the host does. It also rejects an out-of-range enum
+// or variant case, which the component ABI traps on before the host sees it.
+//
+// Nodes are raised in arena order, so a child shared by several parents (the
+// arena allows a DAG) is raised once and shared, not re-expanded.
+func raise(t ui.Tree) (Render, error) {
+	nodes := t.Nodes.Slice()
+	if len(nodes) > MaxNodes {
+		return Render{}, errors.New("node cap exceeded: " + strconv.Itoa(len(nodes)) + " > " + strconv.Itoa(MaxNodes))
+	}
+	raised := make([]Render, len(nodes))
+	for i := range nodes {
+		r, err := raiseNode(&nodes[i], uint32(i), raised)
+		if err != nil {
+			return Render{}, errors.New("node " + strconv.Itoa(i) + ": " + err.Error())
+		}
+		raised[i] = r
+	}
+	if int(t.Root) >= len(raised) {
+		return Render{}, errors.New("root out of range")
+	}
+	return raised[t.Root], nil
+}
+
+func raiseNode(n *ui.Node, idx uint32, raised []Render) (Render, error) {
+	// child resolves a child index, which must point backwards (lower emits
+	// children before their parent).
+	child := func(c uint32) (Render, error) {
+		if c >= idx {
+			return Render{}, errors.New("malformed arena: non-forward ref " + strconv.Itoa(int(c)) + " >= " + strconv.Itoa(int(idx)))
+		}
+		return raised[c], nil
+	}
+	switch {
+	case n.Text() != nil:
+		t := n.Text()
+		c, err := raiseColor(t.Color)
+		r := Render{kind: kText, text: t.Content, color: c}
+		if s := t.Size.Some(); s != nil {
+			r.size, r.hasSize = *s, true
+		}
+		return r, err
+	case n.Row() != nil || n.Column() != nil:
+		l, kind := n.Row(), kRow
+		if l == nil {
+			l, kind = n.Column(), kColumn
+		}
+		if l.Align > types.AlignEnd {
+			return Render{}, errors.New("bad align " + strconv.Itoa(int(l.Align)))
+		}
+		r := Render{kind: kind, spacing: l.Spacing, align: l.Align}
+		for _, c := range l.Children.Slice() {
+			k, err := child(c)
+			if err != nil {
+				return Render{}, err
+			}
+			r.kids = append(r.kids, k)
+		}
+		return r, nil
+	case n.Container() != nil:
+		b := n.Container()
+		k, err := child(b.Child)
+		return Render{kind: kContainer, padding: b.Padding, kids: []Render{k}}, err
+	case n.MouseArea() != nil:
+		h := n.MouseArea()
+		k, err := child(h.Child)
+		return Render{kind: kMouseArea, hitID: truncID(h.ID), kids: []Render{k}}, err
+	case n.Icon() != nil:
+		i := n.Icon()
+		if Icon(i.ID) > IconSnowflake {
+			return Render{}, errors.New("bad icon id " + strconv.Itoa(int(i.ID)))
+		}
+		c, err := raiseColor(i.Color)
+		return Render{kind: kIcon, icon: Icon(i.ID), isize: i.Size, color: c}, err
+	case n.Graph() != nil:
+		g := n.Graph()
+		if g.Kind > types.GraphKindGeneric {
+			return Render{}, errors.New("bad graph kind " + strconv.Itoa(int(g.Kind)))
+		}
+		c, err := raiseColor(g.Line)
+		return Render{kind: kGraph, values: g.Values.Slice(), gkind: g.Kind, color: c}, err
+	case n.Chart() != nil:
+		ch := n.Chart()
+		c, err := raiseColor(ch.Line)
+		return Render{kind: kChart, values: ch.Values.Slice(), color: c, width: ch.Width, height: ch.Height}, err
+	case n.Spacer() != nil:
+		return Render{kind: kSpacer, width: *n.Spacer()}, nil
+	default:
+		return Render{}, errors.New("bad node case " + strconv.Itoa(int(n.Tag())))
+	}
+}
+
+func raiseColor(p ui.Paint) (Color, error) {
+	if tok := p.Token(); tok != nil && *tok > types.ThemeTokenBg {
+		return Color{}, errors.New("bad theme token " + strconv.Itoa(int(*tok)))
+	}
+	if p.Token() == nil && p.Rgba() == nil {
+		return Color{}, errors.New("bad paint case " + strconv.Itoa(int(p.Tag())))
+	}
+	return colorOf(p), nil
+}
+
+// truncID cuts id to MaxIDLen characters, as the host's lift does.
+func truncID(id string) string {
+	n := 0
+	for i := range id {
+		if n == MaxIDLen {
+			return id[:i]
+		}
+		n++
+	}
+	return id
+}
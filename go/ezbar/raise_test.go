@@ -0,0 +1,210 @@
+package ezbar
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/birdayz/ezbar/go/internal/ezbar/plugin/types"
+	"github.com/birdayz/ezbar/go/internal/ezbar/plugin/ui"
+	"go.bytecodealliance.org/cm"
+)
+
+// gen builds a canonical Render from fuzz bytes: only the setters that apply to
+// each kind, so lower keeps everything it's given.
+type gen struct{ b []byte }
+
+func (g *gen) byte() byte {
+	if len(g.b) == 0 {
+		return 0
+	}
+	c := g.b[0]
+	g.b = g.b[1:]
+	return c
+}
+
+func (g *gen) num() float32 { return float32(int8(g.byte())) / 2 }
+
+func (g *gen) str() string {
+	n := int(g.byte() % 12)
+	var s strings.Builder
+	for range n {
+		s.WriteRune(rune(' ' + g.byte()%95))
+	}
+	if g.byte()%4 == 0 {
+		s.WriteString("→é")
+	}
+	return s.String()
+}
+
+func (g *gen) color() Color {
+	c := g.byte()
+	if c%3 == 0 {
+		return RGBA(g.byte(), g.byte(), g.byte(), g.byte())
+	}
+	return Color{tok: types.ThemeToken(c % 7)}
+}
+
+func (g *gen) values() []float64 {
+	n := int(g.byte() % 6)
+	if n == 0 {
+		return nil
+	}
+	vs := make([]float64, n)
+	for i := range vs {
+		vs[i] = float64(g.num())
+	}
+	return vs
+}
+
+func (g *gen) render(depth int) Render {
+	k := g.byte() % 9
+	if depth > 5 && k < 5 {
+		k += 5 // leaves only, so generation terminates
+	}
+	switch k {
+	case 0, 1:
+		var kids []Render // nil when empty, as Row() makes it
+		for range g.byte() % 4 {
+			kids = append(kids, g.render(depth+1))
+		}
+		r := Row(kids...)
+		if k == 1 {
+			r = Column(kids...)
+		}
+		return r.Spacing(g.num()).Align(types.Align(g.byte() % 3))
+	case 2:
+		return Container(g.render(depth + 1)).Padding(g.num())
+	case 3:
+		return MouseArea(g.str(), g.render(depth+1))
+	case 4, 5:
+		r := Text(g.str()).Color(g.color())
+		if g.byte()%2 == 0 {
+			r = r.Size(g.num())
+		}
+		return r
+	case 6:
+		return Icon(g.byte()%(byte(IconSnowflake)+1)).View(g.num(), g.color())
+	case 7:
+		if g.byte()%2 == 0 {
+			return Graph{Values: g.values(), Kind: types.GraphKind(g.byte() % 5), Line: g.color()}.View()
+		}
+		return Chart{Values: g.values(), Line: g.color(), Width: g.num(), Height: g.num()}.View()
+	default:
+		return Spacer(g.num())
+	}
+}
+
+func FuzzLowerRaise(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 3, 6, 14, 28, 0, 4, 5, 'h', 'i', 1})
+	f.Add([]byte{1, 2, 3, 3, 2, 1, 0, 9, 7, 0, 4, 1, 2, 3})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		g := gen{data}
+		r := g.render(0)
+		tree := lower(r)
+		nodes := tree.Nodes.Slice()
+		if int(tree.Root) != len(nodes)-1 {
+			t.Fatalf("root %d, want the last node %d", tree.Root, len(nodes)-1)
+		}
+		for i := range nodes {
+			for _, c := range children(&nodes[i]) {
+				if c >= uint32(i) {
+					t.Fatalf("node %d (%v) points forward to %d", i, nodes[i].String(), c)
+				}
+			}
+		}
+		got, err := raise(tree)
+		if err != nil {
+			t.Fatalf("raise(lower(%v)): %v", r, err)
+		}
+		if !reflect.DeepEqual(got, r) {
+			t.Fatalf("raise(lower(r)) != r\n got %v\nwant %v", got, r)
+		}
+	})
+}
+
+// FuzzRaise feeds raise arbitrary arenas: it must never panic, and a tree it
+// accepts must lower to an arena it accepts again.
+func FuzzRaise(f *testing.F) {
+	f.Add([]byte{8, 0, 1, 0, 0}, uint32(1))
+	f.Add([]byte{8, 1, 1, 2, 0, 1}, uint32(2))
+	f.Fuzz(func(t *testing.T, data []byte, root uint32) {
+		g := gen{data}
+		var nodes []ui.Node
+		for len(g.b) > 0 && len(nodes) < MaxNodes+2 {
+			idx := func() uint32 { return uint32(g.byte()) % uint32(len(nodes)+2) } // may point forward
+			switch g.byte() % 11 {
+			case 0:
+				nodes = append(nodes, ui.NodeRow(ui.LayoutNode{Children: cm.ToList([]uint32{idx(), idx()}), Align: types.Align(g.byte() % 4)}))
+			case 1:
+				nodes = append(nodes, ui.NodeColumn(ui.LayoutNode{Children: cm.ToList([]uint32{idx()})}))
+			case 2:
+				nodes = append(nodes, ui.NodeContainer(ui.BoxNode{Child: idx()}))
+			case 3:
+				nodes = append(nodes, ui.NodeMouseArea(ui.HitNode{Child: idx(), ID: strings.Repeat("é", int(g.byte()))}))
+			case 4:
+				nodes = append(nodes, ui.NodeIcon(ui.IconNode{ID: types.IconID(g.byte()), Color: types.PaintToken(types.ThemeToken(g.byte() % 9))}))
+			case 5:
+				nodes = append(nodes, ui.NodeGraph(ui.GraphNode{Kind: types.GraphKind(g.byte() % 7), Line: types.PaintRgba(types.Rgba8{R: g.byte()})}))
+			case 6:
+				nodes = append(nodes, ui.NodeText(ui.TextNode{Content: g.str(), Color: types.PaintToken(types.ThemeToken(g.byte() % 7))}))
+			case 7:
+				nodes = append(nodes, cm.New[ui.Node](uint8(10+g.byte()%3), float32(0))) // bad case
+			default:
+				nodes = append(nodes, ui.NodeSpacer(g.num()))
+			}
+		}
+		r, err := raise(ui.Tree{Nodes: cm.ToList(nodes), Root: root})
+		if err != nil {
+			return
+		}
+		if r.Validate() != nil {
+			return // the host would render it truncated; lower can't be checked against it
+		}
+		if _, err := raise(lower(r)); err != nil {
+			t.Fatalf("raise accepted %v, but not its own lowering: %v", r, err)
+		}
+	})
+}
+
+func TestRaiseRejects(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		nodes []ui.Node
+		root  uint32
+		want  string
+	}{
+		{"forward ref", []ui.Node{ui.NodeContainer(ui.BoxNode{Child: 0})}, 0, "non-forward ref 0 >= 0"},
+		{"root", []ui.Node{ui.NodeSpacer(1)}, 1, "root out of range"},
+		{"empty", nil, 0, "root out of range"},
+		{"cap", make([]ui.Node, MaxNodes+1), 0, "node cap exceeded: 2001 > 2000"},
+		{"icon", []ui.Node{ui.NodeIcon(ui.IconNode{ID: 200})}, 0, "bad icon id 200"},
+	} {
+		_, err := raise(ui.Tree{Nodes: cm.ToList(tc.nodes), Root: tc.root})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want %q", tc.name, err, tc.want)
+		}
+	}
+
+	long := strings.Repeat("é", MaxIDLen+3)
+	r, err := raise(lower(MouseArea(long, Spacer(1))))
+	if err != nil || r.hitID != long[:2*MaxIDLen] {
+		t.Errorf("raise: id %q, %v; want it cut to %d characters", r.hitID, err, MaxIDLen)
+	}
+}
+
+// children are the arena indices n refers to.
+func children(n *ui.Node) []uint32 {
+	switch {
+	case n.Row() != nil:
+		return n.Row().Children.Slice()
+	case n.Column() != nil:
+		return n.Column().Children.Slice()
+	case n.Container() != nil:
+		return []uint32{n.Container().Child}
+	case n.MouseArea() != nil:
+		return []uint32{n.MouseArea().Child}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package ezbar
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.bytecodealliance.org/cm"
+)
+
+// Record traces the plugin for a later replay: every input the host delivers
+// (config, events, restored state, a call for the chip or popup) and every
+// answer a host call returns (HTTP bodies, files, feed values, …) or the
+// SDK's clock gives, in order. ezbartest.Replay re-drives a native build of
+// the plugin from the trace, deterministically, so a bug seen once on the bar
+// can be stepped through under `go test`.
+//
+// With path "" each record is logged, prefixed "ezbar-trace "; Replay picks
+// those lines out of a copy of the bar's log. Today's hosts give a plugin no
+// writable dir, so on the bar that's the way to record. Otherwise — in a
+// native build, say — the trace goes to the file at path, written a record
+// at a time so it survives a trap; if the file can't be created, Record says
+// so once in the log and logs the trace instead. Traces carry whole response
+// bodies, so leave Record out of release builds:
+//
+//	func init() { ezbar.Register(&My{}, ezbar.Record("")) }
+//
+// The format is line-based text, one record per line: a kind word, then
+// fields that are numbers, bare words or Go-quoted strings (bytes too).
+func Record(path string) Option {
+	return func(o *options) { o.trace = &trace{path: path} }
+}
+
+// tracePrefix marks a trace record in the log.
+const tracePrefix = "ezbar-trace "
+
+// exportCtx is the Ctx the exports hand the plugin: the host, or a recorder
+// around it under [Record].
+var exportCtx Ctx = hostCtx{}
+
+// trace writes records. A nil *trace records nothing, so the exports call it
+// unconditionally.
+type trace struct {
+	path    string
+	f       *os.File // opened on the first record; nil logs instead
+	opened  bool
+	streams int // HTTPOpen streams numbered so far
+}
+
+func (t *trace) emit(fields ...string) {
+	if t == nil {
+		return
+	}
+	line := strings.Join(fields, " ")
+	if !t.opened {
+		t.opened = true
+		if t.path != "" {
+			f, err := os.Create(t.path)
+			if err != nil {
+				hostCtx{}.Log("ezbar: record: " + err.Error() + "; logging the trace instead")
+			}
+			t.f = f
+		}
+	}
+	if t.f != nil {
+		if _, err := t.f.WriteString(line + "\n"); err == nil {
+			return
+		}
+	}
+	hostCtx{}.Log(tracePrefix + line)
+}
+
+// config records a config delivery: kind is "init" or "config" (an
+// EvConfig), px the text size the SDK read for Em alongside it.
+func (t *trace) config(kind string, px float32, config cm.List[[2]string]) {
+	if t == nil {
+		return
+	}
+	fields := []string{kind, num(px)}
+	for _, kv := range config.Slice() {
+		fields = append(fields, quote(kv[0]), quote(kv[1]))
+	}
+	t.emit(fields...)
+}
+
+func (t *trace) event(ev Event) {
+	switch ev.Kind {
+	case EvPointer:
+		t.emit("pointer", quote(ev.PointerID), itoa(int(ev.PointerKind)), num(ev.Delta))
+	case EvFeed:
+		t.emit("feed", itoa(int(ev.Feed)), num64(ev.Value))
+	default:
+		t.emit("timer")
+	}
+}
+
+// done records what Update returned, so a replay can tell when it diverges.
+func (t *trace) done(redraw bool) { t.emit("done", strconv.FormatBool(redraw)) }
+
+func (t *trace) restore(state []byte) { t.emit("restore", quoteBytes(state)) }
+
+// answer records a host call's outcome: head then "ok" and the result
+// fields, or head then "err" and the message.
+func (t *trace) answer(head []string, err error, result ...string) {
+	if err != nil {
+		t.emit(append(head, "err", quote(err.Error()))...)
+		return
+	}
+	t.emit(append(append(head, "ok"), result...)...)
+}
+
+func quote(s string) string      { return strconv.Quote(s) }
+func quoteBytes(b []byte) string { return strconv.Quote(string(b)) }
+func itoa(n int) string          { return strconv.Itoa(n) }
+func num64(v float64) string     { return strconv.FormatFloat(v, 'g', -1, 64) }
+
+// recorder is the Ctx a plugin sees under [Record]: it forwards every call to
+// the host and traces each answer. Calls that answer nothing (Log,
+// SetTimeout, the subscriptions) aren't traced — a replay re-makes them. The
+// methods of CtxV2 and later live in record_v*.go, each built where Ctx has
+// them.
+type recorder struct {
+	Ctx
+	t *trace
+}
+
+func (r recorder) TextSize() float32 {
+	px := r.Ctx.TextSize()
+	r.t.emit("textsize", num(px))
+	return px
+}
+
+// themeNames names the theme tokens, in a trace and in config values.
+var themeNames = []struct {
+	c    Color
+	name string
+}{{Fg, "fg"}, {FgDim, "fg-dim"}, {Accent, "accent"}, {OK, "ok"}, {Warn, "warn"}, {Urgent, "urgent"}, {Bg, "bg"}}
+
+func (r recorder) Fg() Color {
+	c := r.Ctx.Fg()
+	if cr, cg, cb, ca, ok := c.RGBA(); ok {
+		r.t.emit("fg", "rgba", itoa(int(cr)), itoa(int(cg)), itoa(int(cb)), itoa(int(ca)))
+		return c
+	}
+	for _, tok := range themeNames {
+		if tok.c == c {
+			r.t.emit("fg", tok.name)
+			return c
+		}
+	}
+	r.t.emit("fg", "token", itoa(int(c.tok))) // one this SDK has no name for
+	return c
+}
+
+func (r recorder) HTTPGet(url string) ([]byte, error) {
+	body, err := r.Ctx.HTTPGet(url)
+	r.t.answer([]string{"http", quote(url)}, err, quoteBytes(body))
+	return body, err
+}
+
+func (r recorder) ReadFile(path string) ([]byte, error) {
+	data, err := r.Ctx.ReadFile(path)
+	var pe *fs.PathError
+	switch {
+	case errors.Is(err, fs.ErrPermission):
+		r.t.emit("read", quote(path), "denied")
+	case errors.As(err, &pe):
+		r.t.answer([]string{"read", quote(path)}, pe.Err)
+	default:
+		r.t.answer([]string{"read", quote(path)}, err, quoteBytes(data))
+	}
+	return data, err
+}
+
+// stream traces what each Read of an HTTPOpen body returned, under the
+// number the open was given.
+type stream struct {
+	io.ReadCloser
+	t  *trace
+	id string
+}
+
+func (s *stream) Read(p []byte) (int, error) {
+	n, err := s.ReadCloser.Read(p)
+	if n > 0 {
+		s.t.emit("chunk", s.id, quoteBytes(p[:n]))
+	}
+	switch {
+	case err == io.EOF:
+		s.t.emit("eof", s.id)
+	case err != nil:
+		s.t.emit("rerr", s.id, quote(err.Error()))
+	}
+	return n, err
+}
@@ -0,0 +1,23 @@
+//go:build !ezbar_wit_v1
+
+package ezbar
+
+func (r recorder) SwaySnapshot() (SwayState, error) {
+	s, err := r.Ctx.SwaySnapshot()
+	fields := []string{quote(s.Title)}
+	for _, ws := range s.Workspaces {
+		flags := ""
+		if ws.Focused {
+			flags += "f"
+		}
+		if ws.Visible {
+			flags += "v"
+		}
+		if ws.Urgent {
+			flags += "u"
+		}
+		fields = append(fields, quote(ws.Name), quote(flags))
+	}
+	r.t.answer([]string{"sway"}, err, fields...)
+	return s, err
+}
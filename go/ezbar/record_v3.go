@@ -0,0 +1,9 @@
+//go:build !(ezbar_wit_v1 || ezbar_wit_v2)
+
+package ezbar
+
+func (r recorder) Exec(program string, args []string, stdin []byte) (ExecOutput, error) {
+	out, err := r.Ctx.Exec(program, args, stdin)
+	r.t.answer([]string{"exec", quote(program)}, err, itoa(out.Code), quoteBytes(out.Stdout), quoteBytes(out.Stderr))
+	return out, err
+}
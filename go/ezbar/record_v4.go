@@ -0,0 +1,13 @@
+//go:build !(ezbar_wit_v1 || ezbar_wit_v2 || ezbar_wit_v3)
+
+package ezbar
+
+func (r recorder) Pick(prompt string, items []string, current int) (string, bool) {
+	choice, ok := r.Ctx.Pick(prompt, items, current)
+	if ok {
+		r.t.emit("pick", quote(prompt), "ok", quote(choice))
+	} else {
+		r.t.emit("pick", quote(prompt), "none")
+	}
+	return choice, ok
+}
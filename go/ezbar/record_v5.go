@@ -0,0 +1,9 @@
+//go:build !(ezbar_wit_v1 || ezbar_wit_v2 || ezbar_wit_v3 || ezbar_wit_v4)
+
+package ezbar
+
+func (r recorder) LocalTimezone() string {
+	name := r.Ctx.LocalTimezone()
+	r.t.emit("tz", quote(name))
+	return name
+}
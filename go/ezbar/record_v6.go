@@ -0,0 +1,19 @@
+//go:build ezbar_wit_v6 || !(ezbar_wit_v1 || ezbar_wit_v2 || ezbar_wit_v3 || ezbar_wit_v4 || ezbar_wit_v5)
+
+package ezbar
+
+import "io"
+
+// HTTPOpen numbers each stream it opens, so the reads of interleaved bodies
+// stay apart in the trace.
+func (r recorder) HTTPOpen(url string) (io.ReadCloser, error) {
+	body, err := r.Ctx.HTTPOpen(url)
+	if err != nil {
+		r.t.answer([]string{"open", quote(url)}, err)
+		return nil, err
+	}
+	r.t.streams++
+	id := itoa(r.t.streams)
+	r.t.answer([]string{"open", quote(url)}, nil, id)
+	return &stream{ReadCloser: body, t: r.t, id: id}, nil
+}
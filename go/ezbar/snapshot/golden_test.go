@@ -0,0 +1,28 @@
+//go:build !wasm
+
+package snapshot_test
+
+import (
+	"testing"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+	"github.com/birdayz/ezbar/go/ezbar/ezbartest"
+	"github.com/birdayz/ezbar/go/ezbar/snapshot"
+)
+
+func TestGolden(t *testing.T) {
+	chip := ezbar.Row(
+		ezbar.IconCPU.View(ezbar.Em(1.2), ezbar.Accent),
+		ezbar.Text("42%").Color(ezbar.OK),
+		ezbar.Graph{Values: []float64{10, 30, 20, 60, 80, 40, 50}, Kind: ezbar.GraphCPU, Line: ezbar.Accent}.View(),
+	).Spacing(6)
+	ezbartest.AssertSnapshot(t, "chip", chip, snapshot.Options{Scale: 2, Padding: 4})
+
+	popup := ezbar.Column(
+		ezbar.Row(ezbar.IconCloudSun.View(20, ezbar.Warn), ezbar.Text("Berlin").Size(16)).Spacing(8),
+		ezbar.Text("feels like 3°").Color(ezbar.FgDim),
+		ezbar.Chart{Values: []float64{3, 4, 6, 5, 8, 7, 9}, Line: ezbar.Accent, Width: 160, Height: 40}.View(),
+		ezbar.Container(ezbar.Row(ezbar.IconGithub.View(14, ezbar.Fg), ezbar.Text("3 PRs")).Spacing(4)).Padding(4),
+	).Spacing(6)
+	ezbartest.AssertSnapshot(t, "popup", popup, snapshot.Options{Scale: 2, Padding: 8})
+}
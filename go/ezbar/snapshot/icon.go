@@ -0,0 +1,333 @@
+//go:build !wasm
+
+package snapshot
+
+import (
+	"bytes"
+	"embed"
+	"encoding/xml"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+)
+
+// The host's icon set, copied from crates/ezbar-plugin/assets/icons (a test
+// keeps the copy in step).
+//
+//go:generate sh -c "cp ../../../crates/ezbar-plugin/assets/icons/*.svg icons/"
+//go:embed icons/*.svg
+var iconFiles embed.FS
+
+// glyph is an icon parsed once into 24-unit viewBox space: Lucide's are
+// stroked outlines, Simple Icons' (the brands) filled.
+type glyph struct {
+	box    float32 // the viewBox edge
+	stroke *shape
+	fill   *shape
+}
+
+var (
+	glyphsOnce sync.Once
+	glyphs     map[ezbar.Icon]glyph
+)
+
+// iconShapes draws icon in a size×size square at (x, y), tinted c the way the
+// bar's svg colour filter tints it. An unknown id draws nothing.
+func iconShapes(icon ezbar.Icon, x, y, size float32, c color.NRGBA) []*shape {
+	glyphsOnce.Do(loadGlyphs)
+	g, ok := glyphs[icon]
+	if !ok {
+		return nil
+	}
+	k := size / g.box
+	var out []*shape
+	for _, s := range []*shape{g.fill, g.stroke} {
+		if s == nil || len(s.paths) == 0 {
+			continue
+		}
+		t := &shape{fill: s.fill, width: s.width * k, round: s.round, color: c, closed: s.closed}
+		for _, sub := range s.paths {
+			moved := make([]pt, len(sub))
+			for i, q := range sub {
+				moved[i] = pt{x + q.x*k, y + q.y*k}
+			}
+			t.paths = append(t.paths, moved)
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+func loadGlyphs() {
+	glyphs = map[ezbar.Icon]glyph{}
+	for id := ezbar.IconCPU; id <= ezbar.IconSnowflake; id++ {
+		data, err := iconFiles.ReadFile("icons/" + id.String() + ".svg")
+		if err != nil {
+			continue
+		}
+		if g, ok := parseGlyph(data); ok {
+			glyphs[id] = g
+		}
+	}
+}
+
+// parseGlyph reads the SVG subset the icon set uses: path, circle, line,
+// polyline and (rounded) rect, under a root that says whether to fill or
+// stroke and how wide.
+func parseGlyph(data []byte) (glyph, bool) {
+	g := glyph{box: 24}
+	var paint *path
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			break
+		}
+		el, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		attr := func(name string) string {
+			for _, a := range el.Attr {
+				if a.Name.Local == name {
+					return a.Value
+				}
+			}
+			return ""
+		}
+		num := func(name string) float32 {
+			f, _ := strconv.ParseFloat(attr(name), 32)
+			return float32(f)
+		}
+		if el.Name.Local == "svg" {
+			if vb := strings.Fields(attr("viewBox")); len(vb) == 4 {
+				if f, err := strconv.ParseFloat(vb[2], 32); err == nil && f > 0 {
+					g.box = float32(f)
+				}
+			}
+			if attr("stroke") != "" && attr("stroke") != "none" {
+				w := num("stroke-width")
+				if w == 0 {
+					w = 1
+				}
+				g.stroke = &shape{width: w, round: attr("stroke-linecap") == "round"}
+				paint = &path{s: g.stroke}
+			}
+			if attr("fill") != "none" {
+				g.fill = &shape{fill: true}
+				if paint == nil {
+					paint = &path{s: g.fill}
+				}
+			}
+			continue
+		}
+		if paint == nil {
+			return g, false
+		}
+		switch el.Name.Local {
+		case "path":
+			pathData(paint, attr("d"))
+		case "circle":
+			paint.circle(pt{num("cx"), num("cy")}, num("r"))
+		case "line":
+			paint.moveTo(pt{num("x1"), num("y1")})
+			paint.lineTo(pt{num("x2"), num("y2")})
+		case "polyline", "polygon":
+			ns := scanner{s: attr("points")}
+			for first := true; ; first = false {
+				x, ok1 := ns.num()
+				y, ok2 := ns.num()
+				if !ok1 || !ok2 {
+					break
+				}
+				if first {
+					paint.moveTo(pt{x, y})
+				} else {
+					paint.lineTo(pt{x, y})
+				}
+			}
+			if el.Name.Local == "polygon" {
+				paint.close()
+			}
+		case "rect":
+			rect(paint, num("x"), num("y"), num("width"), num("height"), num("rx"))
+		}
+	}
+	return g, paint != nil
+}
+
+func rect(p *path, x, y, w, h, r float32) {
+	r = min(r, w/2, h/2)
+	p.moveTo(pt{x + r, y})
+	p.lineTo(pt{x + w - r, y})
+	p.arcTo(float64(r), float64(r), 0, false, true, pt{x + w, y + r})
+	p.lineTo(pt{x + w, y + h - r})
+	p.arcTo(float64(r), float64(r), 0, false, true, pt{x + w - r, y + h})
+	p.lineTo(pt{x + r, y + h})
+	p.arcTo(float64(r), float64(r), 0, false, true, pt{x, y + h - r})
+	p.lineTo(pt{x, y + r})
+	p.arcTo(float64(r), float64(r), 0, false, true, pt{x + r, y})
+	p.close()
+}
+
+// argc is how many numbers each path command takes.
+var argc = map[byte]int{'m': 2, 'l': 2, 'h': 1, 'v': 1, 'c': 6, 's': 4, 'q': 4, 't': 2, 'a': 7, 'z': 0}
+
+// pathData runs an SVG path's d attribute on p.
+func pathData(p *path, d string) {
+	s := scanner{s: d}
+	p.cur = pt{} // a leading relative moveto is taken from the origin
+	var cmd byte
+	ctrl := pt{nan, nan} // the last curve's last control point, for S and T
+	for {
+		if c, ok := s.command(); ok {
+			cmd = c
+		} else if cmd == 0 {
+			return
+		}
+		rel := cmd >= 'a'
+		at := func(x, y float32) pt {
+			if rel {
+				return pt{p.cur.x + x, p.cur.y + y}
+			}
+			return pt{x, y}
+		}
+		var nums [7]float32
+		for i := range argc[cmd|0x20] {
+			var ok bool
+			if cmd|0x20 == 'a' && (i == 3 || i == 4) {
+				nums[i], ok = s.flag()
+			} else {
+				nums[i], ok = s.num()
+			}
+			if !ok {
+				return
+			}
+		}
+		prev := ctrl
+		ctrl = pt{nan, nan}
+		switch cmd | 0x20 {
+		case 'm':
+			p.moveTo(at(nums[0], nums[1]))
+			if rel {
+				cmd = 'l'
+			} else {
+				cmd = 'L'
+			}
+			continue
+		case 'l':
+			p.lineTo(at(nums[0], nums[1]))
+		case 'h':
+			q := pt{nums[0], p.cur.y}
+			if rel {
+				q.x += p.cur.x
+			}
+			p.lineTo(q)
+		case 'v':
+			q := pt{p.cur.x, nums[0]}
+			if rel {
+				q.y += p.cur.y
+			}
+			p.lineTo(q)
+		case 'c':
+			c1, c2, q := at(nums[0], nums[1]), at(nums[2], nums[3]), at(nums[4], nums[5])
+			p.cubicTo(c1, c2, q)
+			ctrl = c2
+		case 's':
+			c1 := reflect(prev, p.cur)
+			c2, q := at(nums[0], nums[1]), at(nums[2], nums[3])
+			p.cubicTo(c1, c2, q)
+			ctrl = c2
+		case 'q':
+			c, q := at(nums[0], nums[1]), at(nums[2], nums[3])
+			p.quadTo(c, q)
+			ctrl = c
+		case 't':
+			c := reflect(prev, p.cur)
+			p.quadTo(c, at(nums[0], nums[1]))
+			ctrl = c
+		case 'a':
+			p.arcTo(float64(nums[0]), float64(nums[1]), float64(nums[2]), nums[3] != 0, nums[4] != 0, at(nums[5], nums[6]))
+		case 'z':
+			p.close()
+			cmd = 0
+		}
+	}
+}
+
+var nan = float32(math.NaN())
+
+// reflect is the implied first control point of an S or T: the previous
+// curve's last control point mirrored through cur, or cur itself after
+// anything else (marked by a NaN ctrl).
+func reflect(ctrl, cur pt) pt {
+	if ctrl.x != ctrl.x {
+		return cur
+	}
+	return pt{2*cur.x - ctrl.x, 2*cur.y - ctrl.y}
+}
+
+// scanner tokenizes SVG number lists, which may run together ("1.5.5-2").
+type scanner struct{ s string }
+
+func (s *scanner) skip() {
+	s.s = strings.TrimLeft(s.s, " \t\r\n,")
+}
+
+// command reads a path command letter, if one is next.
+func (s *scanner) command() (byte, bool) {
+	s.skip()
+	if s.s == "" {
+		return 0, false
+	}
+	c := s.s[0]
+	if strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", c) < 0 {
+		return 0, false
+	}
+	s.s = s.s[1:]
+	return c, true
+}
+
+func (s *scanner) num() (float32, bool) {
+	s.skip()
+	i, dot, exp := 0, false, false
+	if i < len(s.s) && (s.s[i] == '-' || s.s[i] == '+') {
+		i++
+	}
+scan:
+	for ; i < len(s.s); i++ {
+		switch c := s.s[i]; {
+		case c >= '0' && c <= '9':
+		case c == '.' && !dot && !exp:
+			dot = true
+		case (c == 'e' || c == 'E') && !exp:
+			exp = true
+			if i+1 < len(s.s) && (s.s[i+1] == '-' || s.s[i+1] == '+') {
+				i++
+			}
+		default:
+			break scan
+		}
+	}
+	f, err := strconv.ParseFloat(s.s[:i], 32)
+	if err != nil {
+		return 0, false
+	}
+	s.s = s.s[i:]
+	return float32(f), true
+}
+
+// flag reads an arc flag, which may be written without a separator ("a1 1 0 01-1 1").
+func (s *scanner) flag() (float32, bool) {
+	s.skip()
+	if s.s == "" || (s.s[0] != '0' && s.s[0] != '1') {
+		return 0, false
+	}
+	f := float32(s.s[0] - '0')
+	s.s = s.s[1:]
+	return f, true
+}
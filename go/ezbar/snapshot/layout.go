@@ -0,0 +1,155 @@
+//go:build !wasm
+
+package snapshot
+
+import (
+	"image/color"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+)
+
+// lineHeight is iced's default relative line height, the box a Text (and an
+// empty Spacer line) takes vertically.
+const lineHeight = 1.3
+
+// Graph is drawn at the host's fixed chip-sparkline size.
+const graphW, graphH = 48, 16
+
+// scene is a laid-out tree: what to draw, in px, in paint order.
+type scene struct {
+	w, h  float32
+	bg    color.NRGBA
+	items []item
+}
+
+// item is one text run or one shape.
+type item struct {
+	run   *run
+	shape *shape
+}
+
+// run is a line of text whose line box starts at (x, y).
+type run struct {
+	x, y, size float32
+	text       string
+	color      color.NRGBA
+}
+
+type layout struct {
+	theme Theme
+	items []item
+}
+
+func lay(r ezbar.Render, o Options) scene {
+	l := &layout{theme: o.theme()}
+	w, h := l.measure(r, 0)
+	l.place(r, o.Padding, o.Padding, 0)
+	return scene{w: w + 2*o.Padding, h: h + 2*o.Padding, bg: l.theme.Bg, items: l.items}
+}
+
+// textSize is a Text's px size: its own, else the bar's.
+func (l *layout) textSize(n ezbar.Node) float32 {
+	if n.HasSize {
+		return n.Size
+	}
+	return l.theme.TextSize
+}
+
+// measure is the size the host gives r.
+func (l *layout) measure(r ezbar.Render, depth int) (w, h float32) {
+	if depth > ezbar.MaxDepth {
+		return advance("…", l.theme.TextSize), l.theme.TextSize * lineHeight
+	}
+	n := r.Node()
+	switch n.Kind {
+	case ezbar.NodeText:
+		size := l.textSize(n)
+		return advance(n.Text, size), size * lineHeight
+	case ezbar.NodeRow, ezbar.NodeColumn:
+		for i, c := range n.Children {
+			cw, ch := l.measure(c, depth+1)
+			gap := n.Spacing
+			if i == 0 {
+				gap = 0
+			}
+			if n.Kind == ezbar.NodeRow {
+				w, h = w+gap+cw, max(h, ch)
+			} else {
+				w, h = max(w, cw), h+gap+ch
+			}
+		}
+		return w, h
+	case ezbar.NodeContainer:
+		w, h = l.measure(n.Children[0], depth+1)
+		return w + 2*n.Padding, h + 2*n.Padding
+	case ezbar.NodeMouseArea:
+		return l.measure(n.Children[0], depth+1)
+	case ezbar.NodeIcon:
+		return n.Size, n.Size
+	case ezbar.NodeGraph:
+		return graphW, graphH
+	case ezbar.NodeChart:
+		return n.Width, n.Height
+	default: // a spacer is an empty text line, px wide
+		return n.Width, l.theme.TextSize * lineHeight
+	}
+}
+
+// place lays r out with its top-left corner at (x, y).
+func (l *layout) place(r ezbar.Render, x, y float32, depth int) {
+	if depth > ezbar.MaxDepth {
+		l.items = append(l.items, item{run: &run{x: x, y: y, size: l.theme.TextSize, text: "…", color: l.theme.Fg}})
+		return
+	}
+	n := r.Node()
+	switch n.Kind {
+	case ezbar.NodeText:
+		l.items = append(l.items, item{run: &run{x: x, y: y, size: l.textSize(n), text: n.Text, color: l.theme.Color(n.Color)}})
+	case ezbar.NodeRow, ezbar.NodeColumn:
+		w, h := l.measure(r, depth)
+		for _, c := range n.Children {
+			cw, ch := l.measure(c, depth+1)
+			if n.Kind == ezbar.NodeRow {
+				l.place(c, x, y+offset(n.Align, h, ch, ezbar.AlignCenter), depth+1)
+				x += cw + n.Spacing
+			} else {
+				l.place(c, x+offset(n.Align, w, cw, ezbar.AlignStart), y, depth+1)
+				y += ch + n.Spacing
+			}
+		}
+	case ezbar.NodeContainer:
+		l.place(n.Children[0], x+n.Padding, y+n.Padding, depth+1)
+	case ezbar.NodeMouseArea:
+		l.place(n.Children[0], x, y, depth+1)
+	case ezbar.NodeIcon:
+		for _, s := range iconShapes(n.Icon, x, y, n.Size, l.theme.Color(n.Color)) {
+			l.items = append(l.items, item{shape: s})
+		}
+	case ezbar.NodeGraph:
+		for _, s := range graphShapes(n.Values, n.GraphKind, x, y, graphW, graphH, l.theme.Color(n.Color)) {
+			l.items = append(l.items, item{shape: s})
+		}
+	case ezbar.NodeChart:
+		for _, s := range chartShapes(n.Values, x, y, n.Width, n.Height, l.theme.Color(n.Color), l.theme.Bg) {
+			l.items = append(l.items, item{shape: s})
+		}
+	}
+}
+
+// offset places a child of size along a cross axis of length across. The
+// host reads an out-of-range align as fallback: center for a row, start for a
+// column.
+func offset(a ezbar.Align, across, size float32, fallback ezbar.Align) float32 {
+	switch a {
+	case ezbar.AlignStart, ezbar.AlignCenter, ezbar.AlignEnd:
+	default:
+		a = fallback
+	}
+	switch a {
+	case ezbar.AlignCenter:
+		return (across - size) / 2
+	case ezbar.AlignEnd:
+		return across - size
+	}
+	return 0
+}
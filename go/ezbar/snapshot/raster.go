@@ -0,0 +1,165 @@
+//go:build !wasm
+
+package snapshot
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+var goRegular = sync.OnceValue(func() *opentype.Font {
+	f, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		panic("snapshot: " + err.Error())
+	}
+	return f
+})
+
+var faces sync.Map // float32 px → font.Face
+
+// face is Go Regular at size px, unhinted so widths scale linearly.
+func face(size float32) font.Face {
+	if f, ok := faces.Load(size); ok {
+		return f.(font.Face)
+	}
+	f, err := opentype.NewFace(goRegular(), &opentype.FaceOptions{Size: float64(size), DPI: 72, Hinting: font.HintingNone})
+	if err != nil {
+		panic("snapshot: " + err.Error())
+	}
+	f2, _ := faces.LoadOrStore(size, f)
+	return f2.(font.Face)
+}
+
+// advance is the width of s set at size px.
+func advance(s string, size float32) float32 {
+	return float32(font.MeasureString(face(size), s)) / 64
+}
+
+// baseline is how far below the top of its line box a line of text at size
+// px sits: the glyphs are centred in the box, as iced centres them.
+func baseline(size float32) float32 {
+	m := face(size).Metrics()
+	asc, desc := float32(m.Ascent)/64, float32(m.Descent)/64
+	return (size*lineHeight-(asc+desc))/2 + asc
+}
+
+func raster(sc scene, scale float64) *image.RGBA {
+	k := float32(scale)
+	w := max(int(math.Ceil(float64(sc.w)*scale)), 1)
+	h := max(int(math.Ceil(float64(sc.h)*scale)), 1)
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(sc.bg), image.Point{}, draw.Src)
+	for _, it := range sc.items {
+		if it.run != nil {
+			drawRun(dst, it.run, k)
+		} else {
+			drawShape(dst, it.shape, k)
+		}
+	}
+	return dst
+}
+
+func drawRun(dst *image.RGBA, r *run, k float32) {
+	d := font.Drawer{Dst: dst, Src: image.NewUniform(r.color), Face: face(r.size * k)}
+	d.Dot = fixed.Point26_6{X: fix(r.x * k), Y: fix((r.y + baseline(r.size)) * k)}
+	d.DrawString(r.text)
+}
+
+func fix(v float32) fixed.Int26_6 { return fixed.Int26_6(math.Round(float64(v) * 64)) }
+
+func drawShape(dst *image.RGBA, s *shape, k float32) {
+	b := dst.Bounds()
+	z := vector.NewRasterizer(b.Dx(), b.Dy())
+	scaled := func(sub []pt) []pt {
+		out := make([]pt, len(sub))
+		for i, q := range sub {
+			out[i] = pt{q.x * k, q.y * k}
+		}
+		return out
+	}
+	for i, sub := range s.paths {
+		sub = scaled(sub)
+		if s.fill {
+			polygon(z, sub, false)
+			continue
+		}
+		r := s.width * k / 2
+		if s.closed[i] && len(sub) > 1 {
+			sub = append(sub, sub[0])
+		}
+		for j := 1; j < len(sub); j++ {
+			a, b := sub[j-1], sub[j]
+			dx, dy := b.x-a.x, b.y-a.y
+			l := float32(math.Hypot(float64(dx), float64(dy)))
+			if l == 0 {
+				continue
+			}
+			nx, ny := -dy/l*r, dx/l*r
+			polygon(z, []pt{{a.x + nx, a.y + ny}, {b.x + nx, b.y + ny}, {b.x - nx, b.y - ny}, {a.x - nx, a.y - ny}}, true)
+		}
+		if s.round {
+			for _, q := range sub {
+				disc := &shape{}
+				(&path{s: disc}).circle(q, r)
+				polygon(z, disc.paths[0], true)
+			}
+		}
+	}
+	var src image.Image = image.NewUniform(s.color)
+	if s.ramp != nil {
+		src = rampImage{s.color, s.ramp, k}
+	}
+	z.Draw(dst, b, src, image.Point{})
+}
+
+// polygon adds a closed polygon to z. A stroke is a union of pieces, and the
+// rasterizer only unions pieces wound the same way, so those are normalised
+// to one winding; a fill keeps its own, which is what cuts its holes.
+func polygon(z *vector.Rasterizer, ps []pt, normalise bool) {
+	if len(ps) < 3 {
+		return
+	}
+	if normalise {
+		var area float32
+		for i, p := range ps {
+			q := ps[(i+1)%len(ps)]
+			area += p.x*q.y - q.x*p.y
+		}
+		if area < 0 {
+			rev := make([]pt, len(ps))
+			for i, p := range ps {
+				rev[len(ps)-1-i] = p
+			}
+			ps = rev
+		}
+	}
+	z.MoveTo(ps[0].x, ps[0].y)
+	for _, p := range ps[1:] {
+		z.LineTo(p.x, p.y)
+	}
+	z.ClosePath()
+}
+
+// rampImage paints c with its alpha taken from r by row.
+type rampImage struct {
+	c color.NRGBA
+	r *ramp
+	k float32
+}
+
+func (rampImage) ColorModel() color.Model { return color.NRGBAModel }
+func (rampImage) Bounds() image.Rectangle {
+	return image.Rect(-1e9, -1e9, 1e9, 1e9)
+}
+func (m rampImage) At(_, y int) color.Color {
+	return withAlpha(m.c, m.r.alpha((float32(y)+0.5)/m.k))
+}
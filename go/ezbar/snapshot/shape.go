@@ -0,0 +1,376 @@
+//go:build !wasm
+
+package snapshot
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+)
+
+type pt struct{ x, y float32 }
+
+// shape is a filled (nonzero) or stroked set of flattened subpaths, in px.
+type shape struct {
+	paths  [][]pt
+	closed []bool
+	fill   bool
+	width  float32 // stroke width
+	round  bool    // round caps and joins (else butt caps, no joins)
+	color  color.NRGBA
+	ramp   *ramp // a vertical alpha gradient over color, for fills
+}
+
+// ramp is a vertical gradient: the alpha at stops[i] of the way from y0 to
+// y1, linear in between and clamped outside.
+type ramp struct {
+	y0, y1 float32
+	stops  []stop
+}
+
+type stop struct{ at, alpha float32 }
+
+func (r *ramp) alpha(y float32) float32 {
+	t := (y - r.y0) / (r.y1 - r.y0)
+	if r.y1 == r.y0 || t <= r.stops[0].at {
+		return r.stops[0].alpha
+	}
+	for i := 1; i < len(r.stops); i++ {
+		a, b := r.stops[i-1], r.stops[i]
+		if t <= b.at {
+			return a.alpha + (b.alpha-a.alpha)*(t-a.at)/(b.at-a.at)
+		}
+	}
+	return r.stops[len(r.stops)-1].alpha
+}
+
+// path builds a shape's subpaths the way an SVG path does, flattening curves
+// as it goes.
+type path struct {
+	s     *shape
+	cur   pt
+	start pt
+}
+
+func (p *path) moveTo(q pt) {
+	p.s.paths = append(p.s.paths, []pt{q})
+	p.s.closed = append(p.s.closed, false)
+	p.cur, p.start = q, q
+}
+
+func (p *path) lineTo(q pt) {
+	if len(p.s.paths) == 0 {
+		p.moveTo(p.cur)
+	}
+	i := len(p.s.paths) - 1
+	p.s.paths[i] = append(p.s.paths[i], q)
+	p.cur = q
+}
+
+func (p *path) close() {
+	if len(p.s.paths) > 0 {
+		p.s.closed[len(p.s.closed)-1] = true
+	}
+	p.cur = p.start
+}
+
+// curveSteps is how many segments a Bézier is flattened into: plenty at icon
+// and sparkline sizes.
+const curveSteps = 16
+
+func (p *path) cubicTo(c1, c2, q pt) {
+	a := p.cur
+	for i := 1; i <= curveSteps; i++ {
+		t := float32(i) / curveSteps
+		u := 1 - t
+		p.lineTo(pt{
+			u*u*u*a.x + 3*u*u*t*c1.x + 3*u*t*t*c2.x + t*t*t*q.x,
+			u*u*u*a.y + 3*u*u*t*c1.y + 3*u*t*t*c2.y + t*t*t*q.y,
+		})
+	}
+}
+
+func (p *path) quadTo(c, q pt) {
+	a := p.cur
+	for i := 1; i <= curveSteps; i++ {
+		t := float32(i) / curveSteps
+		u := 1 - t
+		p.lineTo(pt{u*u*a.x + 2*u*t*c.x + t*t*q.x, u*u*a.y + 2*u*t*c.y + t*t*q.y})
+	}
+}
+
+// arcTo is an SVG elliptical arc (rotation phi in degrees) to q, converted
+// from endpoint to centre form (SVG 1.1, appendix F.6).
+func (p *path) arcTo(rx, ry, phi float64, large, sweep bool, q pt) {
+	x1, y1 := float64(p.cur.x), float64(p.cur.y)
+	x2, y2 := float64(q.x), float64(q.y)
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 || (x1 == x2 && y1 == y2) {
+		p.lineTo(q)
+		return
+	}
+	sin, cos := math.Sincos(phi * math.Pi / 180)
+	dx, dy := (x1-x2)/2, (y1-y2)/2
+	x1p, y1p := cos*dx+sin*dy, -sin*dx+cos*dy
+	if l := x1p*x1p/(rx*rx) + y1p*y1p/(ry*ry); l > 1 {
+		rx, ry = rx*math.Sqrt(l), ry*math.Sqrt(l)
+	}
+	num := rx*rx*ry*ry - rx*rx*y1p*y1p - ry*ry*x1p*x1p
+	den := rx*rx*y1p*y1p + ry*ry*x1p*x1p
+	k := math.Sqrt(max(num/den, 0))
+	if large == sweep {
+		k = -k
+	}
+	cxp, cyp := k*rx*y1p/ry, -k*ry*x1p/rx
+	cx, cy := cos*cxp-sin*cyp+(x1+x2)/2, sin*cxp+cos*cyp+(y1+y2)/2
+	angle := func(ux, uy, vx, vy float64) float64 { return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy) }
+	t1 := angle(1, 0, (x1p-cxp)/rx, (y1p-cyp)/ry)
+	dt := angle((x1p-cxp)/rx, (y1p-cyp)/ry, (-x1p-cxp)/rx, (-y1p-cyp)/ry)
+	if !sweep && dt > 0 {
+		dt -= 2 * math.Pi
+	} else if sweep && dt < 0 {
+		dt += 2 * math.Pi
+	}
+	n := max(int(math.Ceil(math.Abs(dt)/(math.Pi/16))), 1)
+	for i := 1; i < n; i++ {
+		t := t1 + dt*float64(i)/float64(n)
+		ex, ey := rx*math.Cos(t), ry*math.Sin(t)
+		p.lineTo(pt{float32(cos*ex - sin*ey + cx), float32(sin*ex + cos*ey + cy)})
+	}
+	p.lineTo(q)
+}
+
+// circle adds a closed circle of radius r around c.
+func (p *path) circle(c pt, r float32) {
+	p.moveTo(pt{c.x + r, c.y})
+	p.arcTo(float64(r), float64(r), 0, false, true, pt{c.x - r, c.y})
+	p.arcTo(float64(r), float64(r), 0, false, true, pt{c.x + r, c.y})
+	p.close()
+}
+
+func withAlpha(c color.NRGBA, a float32) color.NRGBA {
+	c.A = uint8(math.Round(float64(a) * 255))
+	return c
+}
+
+// segments strokes each consecutive pair of pts on its own, as the host's
+// sparkline does (butt caps, no joins).
+func segments(pts []pt, c color.NRGBA) *shape {
+	s := &shape{width: 1.5, color: c}
+	for i := 1; i < len(pts); i++ {
+		s.paths = append(s.paths, []pt{pts[i-1], pts[i]})
+		s.closed = append(s.closed, false)
+	}
+	return s
+}
+
+// fillUnder is the host's sparkline area fill: from the curve down to the
+// bottom edge, fading from 45% at the highest point to clear.
+func fillUnder(pts []pt, bottom float32, c color.NRGBA) *shape {
+	s := &shape{fill: true, color: c}
+	p := &path{s: s}
+	p.moveTo(pt{pts[0].x, bottom})
+	top := bottom
+	for _, q := range pts {
+		p.lineTo(q)
+		top = min(top, q.y)
+	}
+	p.lineTo(pt{pts[len(pts)-1].x, bottom})
+	p.close()
+	s.ramp = &ramp{y0: top, y1: bottom, stops: []stop{{0, 0.45}, {1, 0}}}
+	return s
+}
+
+// graphShapes is the host's Graph (crates/ezbar-plugin/src/ui/graph.rs) at
+// (x, y), w×h, always in the plugin's line colour: Cpu/Memory on a fixed
+// 0..100, Temperature centred or padded 10%, Ping on stepped ceilings and
+// unfilled, Generic auto-fit with 12% headroom. Cpu, Memory and Generic keep
+// the bottom 32% as a floor; negative (and, for Temperature, zero) samples are
+// gaps.
+func graphShapes(values []float64, kind ezbar.GraphKind, x, y, w, h float32, c color.NRGBA) []*shape {
+	n := len(values)
+	xOf := func(i int) float32 { return x + float32(i)*w/max(float32(n)-1, 1) }
+	floor := h * 0.32
+	var pts []pt
+	switch kind {
+	case ezbar.GraphCPU, ezbar.GraphMemory, ezbar.GraphGeneric:
+		lo, hi := 0.0, 100.0
+		keep := func(v float64) bool { return v >= 0 }
+		if kind == ezbar.GraphGeneric {
+			var vs []float64
+			for _, v := range values {
+				if !math.IsNaN(v) && !math.IsInf(v, 0) {
+					vs = append(vs, v)
+				}
+			}
+			if len(vs) < 2 {
+				return nil
+			}
+			values, n = vs, len(vs)
+			lo, hi = math.Inf(1), math.Inf(-1)
+			for _, v := range vs {
+				lo, hi = min(lo, v), max(hi, v)
+			}
+			if hi-lo < 1e-12 {
+				hi = lo + 1
+			}
+			pad := (hi - lo) * 0.12
+			lo, hi = lo-pad, hi+pad
+			keep = func(float64) bool { return true }
+		}
+		for i, v := range values {
+			if keep(v) {
+				pts = append(pts, pt{xOf(i), y + h - floor - float32((v-lo)/(hi-lo))*(h-floor)})
+			}
+		}
+	case ezbar.GraphTemperature:
+		lo, hi, ok := tempRange(values)
+		if !ok {
+			return nil
+		}
+		for i, v := range values {
+			if v > 0 {
+				pts = append(pts, pt{xOf(i), y + h - float32((v-lo)/(hi-lo))*h})
+			}
+		}
+	case ezbar.GraphPing:
+		return pingShapes(values, xOf, y, h, c)
+	default:
+		return nil
+	}
+	if len(pts) == 0 {
+		return nil
+	}
+	var out []*shape
+	if len(pts) > 1 {
+		out = append(out, fillUnder(pts, y+h, c))
+	}
+	return append(out, segments(pts, c))
+}
+
+// tempRange skips unfilled (zero) slots and centres a near-flat reading in a
+// 10° band; otherwise it pads the range by 10%.
+func tempRange(vs []float64) (lo, hi float64, ok bool) {
+	valid := 0
+	for _, t := range vs {
+		if t > 0 {
+			if valid == 0 {
+				lo, hi = t, t
+			}
+			lo, hi = min(lo, t), max(hi, t)
+			valid++
+		}
+	}
+	if valid == 0 {
+		return 0, 0, false
+	}
+	if hi-lo < 10 {
+		mid := (lo + hi) / 2
+		return mid - 5, mid + 5, true
+	}
+	pad := (hi - lo) * 0.1
+	return lo - pad, hi + pad, true
+}
+
+func pingShapes(values []float64, xOf func(int) float32, y, h float32, c color.NRGBA) []*shape {
+	lo, hi, valid := 0.0, 0.0, 0
+	for _, p := range values {
+		if p >= 0 {
+			if valid == 0 || p < lo {
+				lo = p
+			}
+			hi = max(hi, p)
+			valid++
+		}
+	}
+	if valid == 0 {
+		return nil
+	}
+	switch {
+	case hi < 20:
+		hi = 20
+	case hi < 50:
+		hi = 50
+	case hi < 100:
+		hi = 100
+	default:
+		hi *= 1.1
+	}
+	den := hi - lo
+	if den <= 0 {
+		den = 1
+	}
+	s := &shape{width: 1.5, color: c}
+	var prev *pt
+	for i, p := range values {
+		if p < 0 {
+			prev = nil
+			continue
+		}
+		q := pt{xOf(i), y + h - float32((p-lo)/den)*h}
+		if prev != nil {
+			s.paths = append(s.paths, []pt{*prev, q})
+			s.closed = append(s.closed, false)
+		}
+		prev = &q
+	}
+	return []*shape{s}
+}
+
+// chartShapes is the host's MiniTrend at (x, y), w×h: a Catmull-Rom smoothed
+// line over an auto-fit range with 18% headroom, a fading area fill, a soft
+// glow, and an end dot punched out of the line with a bar-coloured halo.
+func chartShapes(values []float64, x, y, w, h float32, c, bg color.NRGBA) []*shape {
+	n := len(values)
+	if n < 2 {
+		return nil
+	}
+	const pad = 2
+	x0, x1 := x+pad, x+max(w-pad, pad+1)
+	top, bottom := y+pad, y+max(h-pad, pad+1)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	if math.Abs(hi-lo) < 1e-12 {
+		hi = lo + 1
+	}
+	r := hi - lo
+	lo, hi = lo-r*0.18, hi+r*0.18
+	pts := make([]pt, n)
+	for i, v := range values {
+		pts[i] = pt{x0 + float32(i)/float32(n-1)*(x1-x0), bottom - float32((v-lo)/(hi-lo))*(bottom-top)}
+	}
+	curve := func(p *path) {
+		clamp := func(q pt) pt { return pt{q.x, min(max(q.y, top), bottom)} }
+		for i := 0; i < n-1; i++ {
+			p0, p1, p2, p3 := pts[max(i-1, 0)], pts[i], pts[i+1], pts[min(i+2, n-1)]
+			c1 := pt{p1.x + (p2.x-p0.x)/6, p1.y + (p2.y-p0.y)/6}
+			c2 := pt{p2.x - (p3.x-p1.x)/6, p2.y - (p3.y-p1.y)/6}
+			p.cubicTo(clamp(c1), clamp(c2), p2)
+		}
+	}
+
+	area := &shape{fill: true, color: c, ramp: &ramp{y0: top, y1: bottom, stops: []stop{{0, 0.55}, {0.6, 0.18}, {1, 0}}}}
+	p := &path{s: area}
+	p.moveTo(pt{pts[0].x, bottom})
+	p.lineTo(pts[0])
+	curve(p)
+	p.lineTo(pt{pts[n-1].x, bottom})
+	p.close()
+
+	glow := &shape{width: 4, round: true, color: withAlpha(c, 0.16)}
+	p = &path{s: glow}
+	p.moveTo(pts[0])
+	curve(p)
+	line := &shape{width: 1.5, round: true, color: c, paths: glow.paths, closed: glow.closed}
+
+	last := pts[n-1]
+	halo := &shape{fill: true, color: withAlpha(bg, 1)}
+	(&path{s: halo}).circle(last, 3.8)
+	lift := func(v uint8) uint8 { return uint8(math.Round(float64(v)*0.6 + 0.4*255)) }
+	dot := &shape{fill: true, color: color.NRGBA{lift(c.R), lift(c.G), lift(c.B), 255}}
+	(&path{s: dot}).circle(last, 1.9)
+	return []*shape{area, glow, line, halo, dot}
+}
@@ -0,0 +1,127 @@
+//go:build !wasm
+
+// Package snapshot draws a [ezbar.Render] tree to a PNG or an SVG without the
+// bar: no GPU, no Wayland, so it runs in CI. Use it for README screenshots,
+// registry listings and — with [ezbartest.AssertImage] — visual regression
+// tests:
+//
+//	f, _ := os.Create("chip.png")
+//	snapshot.PNG(f, chip.View(), snapshot.Options{Scale: 2, Padding: 6})
+//
+// Layout follows the host's widget semantics (see build in crates/ezbar-wasm):
+// rows and columns add their spacing between children and align them on the
+// cross axis, a container pads all four sides, a graph is a fixed 48×16
+// sparkline and a chart its own width×height trend, a spacer is px wide and
+// one empty line tall. Icons are the host's own SVGs (icons/, kept
+// byte-identical to crates/ezbar-plugin/assets/icons), tinted like the bar
+// tints them, and theme tokens resolve through [DefaultTheme], ezbar-harness's
+// DEFAULT_THEME.
+//
+// Text is set in Go Regular (golang.org/x/image/font/gofont), not the user's
+// bar font, so a label's width is close to — not exactly — what the bar
+// shows.
+//
+// [ezbartest.AssertImage]: https://pkg.go.dev/github.com/birdayz/ezbar/go/ezbar/ezbartest#AssertImage
+package snapshot
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+)
+
+// Theme is the palette theme tokens resolve to, and the bar's text size.
+type Theme struct {
+	Fg, FgDim, Urgent, Warn, OK, Accent, Sep, Bg color.NRGBA
+	TextSize                                     float32 // px; the size of a Text with no Size
+}
+
+// DefaultTheme is ezbar-harness's DEFAULT_THEME: the colours a plugin gets
+// under a stock config.
+var DefaultTheme = Theme{
+	Fg:       rgb(1, 1, 1),
+	FgDim:    rgb(0.7, 0.7, 0.7),
+	Urgent:   rgb(1, 0.2, 0.2),
+	Warn:     rgb(1, 0.67, 0),
+	OK:       rgb(0.2, 0.8, 0.2),
+	Accent:   rgb(0.345, 0.65, 1),
+	Sep:      rgb(0.4, 0.4, 0.4),
+	Bg:       rgb(0.05, 0.05, 0.07),
+	TextSize: 14,
+}
+
+// rgb converts the harness's float channels, rounding like iced does.
+func rgb(r, g, b float64) color.NRGBA {
+	c := func(v float64) uint8 { return uint8(math.Round(v * 255)) }
+	return color.NRGBA{c(r), c(g), c(b), 255}
+}
+
+// Color is c under t: a token's theme colour, or the literal RGBA.
+func (t Theme) Color(c ezbar.Color) color.NRGBA {
+	if r, g, b, a, ok := c.RGBA(); ok {
+		return color.NRGBA{r, g, b, a}
+	}
+	switch c {
+	case ezbar.FgDim:
+		return t.FgDim
+	case ezbar.Accent:
+		return t.Accent
+	case ezbar.OK:
+		return t.OK
+	case ezbar.Warn:
+		return t.Warn
+	case ezbar.Urgent:
+		return t.Urgent
+	case ezbar.Bg:
+		return t.Bg
+	}
+	return t.Fg
+}
+
+// Options control a snapshot. The zero value draws at 1× under
+// [DefaultTheme], cropped tight to the tree.
+type Options struct {
+	Theme   *Theme  // nil is DefaultTheme
+	Scale   float64 // device pixels per px; 0 is 1 (use 2 for crisp README shots)
+	Padding float32 // px of bar background around the tree
+}
+
+func (o Options) theme() Theme {
+	if o.Theme == nil {
+		return DefaultTheme
+	}
+	return *o.Theme
+}
+
+func (o Options) scale() float64 {
+	if o.Scale <= 0 {
+		return 1
+	}
+	return o.Scale
+}
+
+// Size is the px size r lays out to, padding included.
+func Size(r ezbar.Render, o Options) (w, h float32) {
+	s := lay(r, o)
+	return s.w, s.h
+}
+
+// Image draws r on the theme's background.
+func Image(r ezbar.Render, o Options) *image.RGBA {
+	return raster(lay(r, o), o.scale())
+}
+
+// PNG writes r as a PNG.
+func PNG(w io.Writer, r ezbar.Render, o Options) error {
+	return png.Encode(w, Image(r, o))
+}
+
+// SVG writes r as an SVG document, px for px (Scale only sets the
+// document's displayed width and height).
+func SVG(w io.Writer, r ezbar.Render, o Options) error {
+	return writeSVG(w, lay(r, o), o.scale())
+}
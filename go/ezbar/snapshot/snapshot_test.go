@@ -0,0 +1,159 @@
+//go:build !wasm
+
+package snapshot
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/birdayz/ezbar/go/ezbar"
+)
+
+// The host's icon assets, which icons/ must mirror.
+const hostIcons = "../../../crates/ezbar-plugin/assets/icons"
+
+func TestIconsMatchHost(t *testing.T) {
+	host, err := os.ReadDir(hostIcons)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ours, err := iconFiles.ReadDir("icons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ours) != len(host) {
+		t.Errorf("%d icons here, %d in %s (run go generate)", len(ours), len(host), hostIcons)
+	}
+	for _, e := range host {
+		want, err := os.ReadFile(filepath.Join(hostIcons, e.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := iconFiles.ReadFile("icons/" + e.Name()); err != nil || !bytes.Equal(got, want) {
+			t.Errorf("icons/%s differs from the host's (run go generate)", e.Name())
+		}
+	}
+}
+
+func TestEveryIconDraws(t *testing.T) {
+	for id := ezbar.IconCPU; id <= ezbar.IconSnowflake; id++ {
+		shapes := iconShapes(id, 0, 0, 24, DefaultTheme.Fg)
+		if len(shapes) == 0 {
+			t.Errorf("%v draws nothing", id)
+		}
+		for _, s := range shapes {
+			for _, sub := range s.paths {
+				for _, q := range sub {
+					if q.x < -1 || q.x > 25 || q.y < -1 || q.y > 25 {
+						t.Errorf("%v strays outside its box: %v", id, q)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestPathData(t *testing.T) {
+	s := &shape{}
+	// implicit lineto after moveto, packed numbers, relative h/v, close
+	pathData(&path{s: s}, "m1 1 2 0v2h-2z M5.5.5 6-1")
+	want := [][]pt{{{1, 1}, {3, 1}, {3, 3}, {1, 3}}, {{5.5, .5}, {6, -1}}}
+	if len(s.paths) != len(want) || !s.closed[0] || s.closed[1] {
+		t.Fatalf("paths %v closed %v", s.paths, s.closed)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if s.paths[i][j] != want[i][j] {
+				t.Errorf("paths[%d][%d] = %v, want %v", i, j, s.paths[i][j], want[i][j])
+			}
+		}
+	}
+	// arc flags written without separators end where they should
+	s = &shape{}
+	pathData(&path{s: s}, "M0 0a1 1 0 012 0")
+	if end := s.paths[0][len(s.paths[0])-1]; end != (pt{2, 0}) {
+		t.Errorf("arc ends at %v, want (2, 0)", end)
+	}
+}
+
+func TestSize(t *testing.T) {
+	line := DefaultTheme.TextSize * lineHeight
+	ab := advance("ab", 14)
+	for _, tc := range []struct {
+		name string
+		r    ezbar.Render
+		o    Options
+		w, h float32
+	}{
+		{"text", ezbar.Text("ab"), Options{}, ab, line},
+		{"sized text", ezbar.Text("ab").Size(10), Options{}, advance("ab", 10), 13},
+		{"row", ezbar.Row(ezbar.Text("ab"), ezbar.Spacer(10), ezbar.IconCPU.View(20, ezbar.Fg)).Spacing(4), Options{}, ab + 4 + 10 + 4 + 20, max(line, 20)},
+		{"column", ezbar.Column(ezbar.Graph{}.View(), ezbar.Chart{Width: 60, Height: 30}.View()).Spacing(2), Options{}, 60, 16 + 2 + 30},
+		{"container", ezbar.Container(ezbar.IconSun.View(10, ezbar.Fg)).Padding(3), Options{Padding: 5}, 10 + 6 + 10, 10 + 6 + 10},
+		{"mouse area", ezbar.MouseArea("x", ezbar.Graph{}.View()), Options{}, 48, 16},
+	} {
+		if w, h := Size(tc.r, tc.o); w != tc.w || h != tc.h {
+			t.Errorf("%s: %v×%v, want %v×%v", tc.name, w, h, tc.w, tc.h)
+		}
+	}
+}
+
+func TestAlign(t *testing.T) {
+	tall := ezbar.IconCPU.View(40, ezbar.Fg)
+	small := ezbar.Text("x").Size(10) // 13 tall
+	w := advance("x", 10)
+	for _, tc := range []struct {
+		r    ezbar.Render
+		x, y float32 // where the text lands
+	}{
+		{ezbar.Row(tall, small), 40, 13.5}, // a row centres by default
+		{ezbar.Row(tall, small).Align(ezbar.AlignStart), 40, 0},
+		{ezbar.Row(tall, small).Align(ezbar.AlignEnd), 40, 27},
+		{ezbar.Column(tall, small), 0, 40}, // a column starts
+		{ezbar.Column(tall, small).Align(ezbar.AlignCenter), (40 - w) / 2, 40},
+		{ezbar.Column(tall, small).Align(ezbar.AlignEnd), 40 - w, 40},
+	} {
+		sc := lay(tc.r, Options{})
+		r := sc.items[len(sc.items)-1].run
+		if r.x != tc.x || r.y != tc.y {
+			t.Errorf("%s: text at (%v, %v), want (%v, %v)", tc.r, r.x, r.y, tc.x, tc.y)
+		}
+	}
+}
+
+func TestSVGWellFormed(t *testing.T) {
+	var b bytes.Buffer
+	r := ezbar.Row(
+		ezbar.IconCloud.View(16, ezbar.Accent),
+		ezbar.Text(`<5°C & "dry">`).Color(ezbar.RGBA(255, 0, 0, 128)),
+		ezbar.Graph{Values: []float64{1, 3, 2}, Kind: ezbar.GraphGeneric, Line: ezbar.OK}.View(),
+		ezbar.Chart{Values: []float64{1, 3, 2}, Width: 40, Height: 20, Line: ezbar.Warn}.View(),
+	)
+	if err := SVG(&b, r, Options{Scale: 2}); err != nil {
+		t.Fatal(err)
+	}
+	d := xml.NewDecoder(&b)
+	texts := 0
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("%v in\n%s", err, b.String())
+		}
+		if c, ok := tok.(xml.CharData); ok && bytes.Contains(c, []byte("dry")) {
+			texts++
+			if string(c) != `<5°C & "dry">` {
+				t.Errorf("text = %q", c)
+			}
+		}
+	}
+	if texts != 1 {
+		t.Errorf("%d text runs", texts)
+	}
+}
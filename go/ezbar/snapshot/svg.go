@@ -0,0 +1,86 @@
+//go:build !wasm
+
+package snapshot
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+func writeSVG(w io.Writer, sc scene, scale float64) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s">`+"\n",
+		num(sc.w*float32(scale)), num(sc.h*float32(scale)), num(sc.w), num(sc.h))
+	fmt.Fprintf(b, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hex(sc.bg))
+	grads := 0
+	for _, it := range sc.items {
+		if r := it.run; r != nil {
+			fmt.Fprintf(b, `<text x="%s" y="%s" font-family="Go, sans-serif" font-size="%s" fill="%s"%s xml:space="preserve">`,
+				num(r.x), num(r.y+baseline(r.size)), num(r.size), hex(r.color), opacity("fill", r.color.A))
+			xml.EscapeText(b, []byte(r.text))
+			b.WriteString("</text>\n")
+			continue
+		}
+		s := it.shape
+		d := pathD(s)
+		switch {
+		case s.ramp != nil:
+			grads++
+			fmt.Fprintf(b, `<defs><linearGradient id="g%d" gradientUnits="userSpaceOnUse" x1="0" y1="%s" x2="0" y2="%s">`, grads, num(s.ramp.y0), num(s.ramp.y1))
+			for _, st := range s.ramp.stops {
+				fmt.Fprintf(b, `<stop offset="%s" stop-color="%s" stop-opacity="%s"/>`, num(st.at), hex(s.color), num(st.alpha))
+			}
+			fmt.Fprintf(b, "</linearGradient></defs>\n"+`<path d="%s" fill="url(#g%d)"/>`+"\n", d, grads)
+		case s.fill:
+			fmt.Fprintf(b, `<path d="%s" fill="%s"%s/>`+"\n", d, hex(s.color), opacity("fill", s.color.A))
+		default:
+			cap, join := "butt", "miter"
+			if s.round {
+				cap, join = "round", "round"
+			}
+			fmt.Fprintf(b, `<path d="%s" fill="none" stroke="%s"%s stroke-width="%s" stroke-linecap="%s" stroke-linejoin="%s"/>`+"\n",
+				d, hex(s.color), opacity("stroke", s.color.A), num(s.width), cap, join)
+		}
+	}
+	b.WriteString("</svg>\n")
+	return b.Flush()
+}
+
+func pathD(s *shape) string {
+	var d strings.Builder
+	for i, sub := range s.paths {
+		for j, q := range sub {
+			if j == 0 {
+				d.WriteByte('M')
+			} else {
+				d.WriteByte('L')
+			}
+			d.WriteString(num(q.x) + " " + num(q.y))
+		}
+		if s.closed[i] {
+			d.WriteByte('Z')
+		}
+	}
+	return d.String()
+}
+
+// num is v to two decimals, trimmed.
+func num(v float32) string {
+	return strconv.FormatFloat(math.Round(float64(v)*100)/100, 'f', -1, 64)
+}
+
+func hex(c color.NRGBA) string { return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B) }
+
+// opacity is the attr attribute for alpha a, or nothing if it's opaque.
+func opacity(attr string, a uint8) string {
+	if a == 255 {
+		return ""
+	}
+	return fmt.Sprintf(` %s-opacity="%s"`, attr, num(float32(a)/255))
+}
@@ -0,0 +1,180 @@
+package ezbar
+
+import (
+	"sort"
+	"time"
+)
+
+// Timers multiplexes named timers onto one one-shot timer, armed for the
+// earliest of them: the SDK's side of Ctx's After, Every and Stop. Its Update
+// hands the plugin a Ctx whose named timers it keeps, on which SetTimeout is
+// the unnamed timer "", and turns an EvTimer into one EvTimer per timer that's
+// due, each with its name. An EvTimer while none is pending — the bootstrap,
+// the heartbeat of a plugin that never arms — passes through with Timer "".
+//
+// [Register] keeps one for the plugin, and [Components] one per child; a
+// tool that drives a plugin outside the host keeps its own. The zero value is
+// ready to use.
+type Timers struct {
+	named  []*namedTimer
+	armed  time.Time // the deadline the underlying timer is set for; zero if none
+	at     time.Time // the time, once read during an Update
+	cancel bool      // a timer was stopped during this Update
+}
+
+type namedTimer struct {
+	name  string
+	due   time.Time
+	every time.Duration // 0 for a one-shot
+}
+
+// Update delivers ev to update through a Ctx that reaches ts, then re-arms
+// ctx's timer for the earliest pending one. It reports whether any call to
+// update asked to re-render.
+func (ts *Timers) Update(ctx Ctx, ev Event, update func(Ctx, Event) bool) bool {
+	ts.at, ts.cancel = time.Time{}, false
+	tc := timerCtx{Ctx: ctx, ts: ts}
+	redraw := false
+	if ev.Kind != EvTimer || len(ts.named) == 0 {
+		redraw = update(tc, ev)
+	} else {
+		ts.armed = time.Time{} // the underlying timer is one-shot
+		for _, name := range ts.fire() {
+			ev.Timer = name
+			redraw = update(tc, ev) || redraw
+		}
+	}
+	ts.arm(ctx)
+	return redraw
+}
+
+// now is the time, read once per Update.
+func (ts *Timers) now() time.Time {
+	if ts.at.IsZero() {
+		ts.at = now()
+	}
+	return ts.at
+}
+
+// fire takes the timers that are due, earliest first, re-scheduling each
+// Every and dropping each After, and returns their names.
+func (ts *Timers) fire() []string {
+	t := ts.now()
+	var due []namedTimer // as they were, for the order
+	keep := ts.named[:0]
+	for _, n := range ts.named {
+		if n.due.After(t.Add(timerSlack)) {
+			keep = append(keep, n)
+			continue
+		}
+		due = append(due, *n)
+		if n.every > 0 {
+			if n.due = n.due.Add(n.every); !n.due.After(t) {
+				n.due = t.Add(n.every)
+			}
+			keep = append(keep, n)
+		}
+	}
+	ts.named = keep
+	sort.SliceStable(due, func(i, j int) bool { return due[i].due.Before(due[j].due) })
+	names := make([]string, len(due))
+	for i, n := range due {
+		names[i] = n.name
+	}
+	return names
+}
+
+func (ts *Timers) set(name string, d, every time.Duration) {
+	due := ts.now().Add(max(d, minTimeout))
+	for _, n := range ts.named {
+		if n.name == name {
+			n.due, n.every = due, every
+			return
+		}
+	}
+	ts.named = append(ts.named, &namedTimer{name: name, due: due, every: every})
+}
+
+func (ts *Timers) stop(name string) {
+	ts.cancel = true
+	for i, n := range ts.named {
+		if n.name == name {
+			ts.named = append(ts.named[:i], ts.named[i+1:]...)
+			return
+		}
+	}
+}
+
+// arm points ctx's timer at the earliest pending deadline, unless it's there
+// already, or cancels it if none is pending and one was stopped.
+func (ts *Timers) arm(ctx Ctx) {
+	var next time.Time
+	for _, n := range ts.named {
+		if next.IsZero() || n.due.Before(next) {
+			next = n.due
+		}
+	}
+	if next.Equal(ts.armed) && !(next.IsZero() && ts.cancel) {
+		return
+	}
+	ts.armed = next
+	if next.IsZero() {
+		ctx.SetTimeout(0)
+		return
+	}
+	ctx.SetTimeout(timeoutMs(next.Sub(ts.now())))
+}
+
+// pluginTimers are the named timers of the plugin [Register] wires up.
+var pluginTimers Timers
+
+// outside runs f on ts between Updates — for a Ctx that isn't one its Update
+// handed out — and re-arms ctx's timer after.
+func (ts *Timers) outside(ctx Ctx, f func(timerCtx)) {
+	ts.at, ts.cancel = time.Time{}, false
+	f(timerCtx{Ctx: ctx, ts: ts})
+	ts.arm(ctx)
+}
+
+// A bare hostCtx keeps its named timers with the plugin's, so they fire
+// however the plugin came by it.
+func (c hostCtx) After(name string, d time.Duration) {
+	pluginTimers.outside(c, func(tc timerCtx) { tc.After(name, d) })
+}
+
+func (c hostCtx) Every(name string, d time.Duration) {
+	pluginTimers.outside(c, func(tc timerCtx) { tc.Every(name, d) })
+}
+
+func (c hostCtx) Stop(name string) {
+	pluginTimers.outside(c, func(tc timerCtx) { tc.Stop(name) })
+}
+
+// timeoutMs is d in whole milliseconds, rounded up so the timer fires at or
+// after the deadline, and at least 1 (0 would cancel).
+func timeoutMs(d time.Duration) uint32 {
+	return uint32(max((d+time.Millisecond-1)/time.Millisecond, 1))
+}
+
+// timerCtx is the Ctx a [Timers] hands the plugin: its named timers are kept
+// in ts, and SetTimeout is the unnamed timer "".
+type timerCtx struct {
+	Ctx
+	ts *Timers
+}
+
+func (c timerCtx) SetTimeout(ms uint32) {
+	if ms == 0 {
+		c.ts.stop("")
+		return
+	}
+	c.ts.set("", time.Duration(ms)*time.Millisecond, 0)
+}
+
+func (c timerCtx) After(name string, d time.Duration) { c.ts.set(name, d, 0) }
+
+func (c timerCtx) Every(name string, d time.Duration) {
+	c.ts.set(name, d, max(d, minTimeout))
+}
+
+func (c timerCtx) Stop(name string) { c.ts.stop(name) }
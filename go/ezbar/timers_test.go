@@ -0,0 +1,107 @@
+//go:build !wasm
+
+package ezbar
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// toaster polls every 5 minutes, counts down every second and expires a
+// toast after 3, all off the one host timer.
+type toaster struct {
+	fired []string
+	at    []time.Duration
+}
+
+func (p *toaster) update(ctx Ctx, ev Event, at time.Duration) bool {
+	if ev.Kind != EvTimer {
+		return false
+	}
+	switch ev.Timer {
+	case "":
+		ctx.After("refresh", 5*time.Minute)
+		ctx.Every("tick", time.Second)
+		ctx.After("toast", 3*time.Second)
+		return true
+	case "refresh":
+		ctx.After("refresh", 5*time.Minute)
+	case "tick":
+		if len(p.fired) > 8 {
+			ctx.Stop("tick")
+		}
+	}
+	p.fired = append(p.fired, ev.Timer)
+	p.at = append(p.at, at)
+	return true
+}
+
+func TestTimers(t *testing.T) {
+	var at time.Duration
+	UseClock(func() time.Time { return time.Unix(0, 0).Add(at) })
+	defer UseClock(nil)
+
+	p := &toaster{}
+	h := &feedHost{}
+	var ts Timers
+	// the host: fire the last timeout armed, until none is.
+	deliver := func() bool {
+		return ts.Update(h, Event{Kind: EvTimer}, func(ctx Ctx, ev Event) bool { return p.update(ctx, ev, at) })
+	}
+	deliver()
+	var armed []uint32
+	for at < 5*time.Minute {
+		ms := h.timeouts[len(h.timeouts)-1]
+		armed = append(armed, ms)
+		at += time.Duration(ms) * time.Millisecond
+		deliver()
+	}
+	wantFired := []string{"tick", "tick", "tick", "toast", "tick", "tick", "tick", "tick", "tick", "tick", "refresh"}
+	wantAt := []time.Duration{1, 2, 3, 3, 4, 5, 6, 7, 8, 9, 300}
+	for i := range wantAt {
+		wantAt[i] *= time.Second
+	}
+	if !reflect.DeepEqual(p.fired, wantFired) || !reflect.DeepEqual(p.at, wantAt) {
+		t.Errorf("fired %q\n   at %v\nwant %q\n   at %v", p.fired, p.at, wantFired, wantAt)
+	}
+	// the host wakes once a second (the toast riding the third), and once the
+	// ticks stop, sleeps straight to the refresh.
+	if want := []uint32{1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 291_000}; !reflect.DeepEqual(armed, want) {
+		t.Errorf("host timeouts %v, want %v", armed, want)
+	}
+}
+
+func TestTimersSetTimeout(t *testing.T) {
+	var at time.Duration
+	UseClock(func() time.Time { return time.Unix(0, 0).Add(at) })
+	defer UseClock(nil)
+
+	var ts Timers
+	h := &feedHost{}
+	var got []string
+	update := func(arm func(Ctx)) {
+		ts.Update(h, Event{Kind: EvTimer}, func(ctx Ctx, ev Event) bool {
+			got = append(got, ev.Timer)
+			arm(ctx)
+			return false
+		})
+	}
+	// SetTimeout is the unnamed timer, floored like the host's, alongside the
+	// named ones.
+	update(func(ctx Ctx) { ctx.SetTimeout(10); ctx.After("x", 50*time.Millisecond) })
+	if want := []uint32{100}; !reflect.DeepEqual(h.timeouts, want) {
+		t.Errorf("host timeouts = %v, want %v", h.timeouts, want)
+	}
+	at = 100 * time.Millisecond
+	update(func(ctx Ctx) {})
+	if want := []string{"", "", "x"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("delivered %q, want %q: two timers due together both fire", got, want)
+	}
+	// a cancel with nothing pending still reaches the host, so a reactive
+	// plugin can stop its heartbeat.
+	update(func(ctx Ctx) { ctx.SetTimeout(0) })
+	if last := h.timeouts[len(h.timeouts)-1]; last != 0 {
+		t.Errorf("host timeout after SetTimeout(0) = %d, want 0", last)
+	}
+}
@@ -0,0 +1,89 @@
+package ezbar
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// localZone is [Local]'s answer, asked for once per host.
+var localZone struct {
+	sync.Once
+	loc *time.Location
+}
+
+//go:generate go run gen_zoneinfo.go
+
+// LoadLocation resolves an IANA zone name (e.g. "Europe/Berlin") against the
+// SDK's embedded rules (see [Local]); an unknown name yields UTC. It needs no
+// host call, so it works under any WIT version — e.g. for a zone from config.
+func LoadLocation(name string) *time.Location {
+	if zone, ok := zoneEntry(name); ok {
+		if loc, err := time.LoadLocationFromTZData(name, tzif(zone)); err == nil {
+			return loc
+		}
+	}
+	if loc, err := time.LoadLocation(name); err == nil { // e.g. the plugin linked time/tzdata
+		return loc
+	}
+	return time.UTC
+}
+
+// zoneEntry finds name's entry in the generated table: its POSIX TZ rule, then
+// any transitions the rule doesn't predict.
+func zoneEntry(name string) (string, bool) {
+	i := strings.Index(zoneRules, "\n"+name+" ")
+	if name == "" || i < 0 {
+		return "", false
+	}
+	line := zoneRules[i+len(name)+2:]
+	return line[:strings.IndexByte(line, '\n')], true
+}
+
+// tzif wraps a table entry in a minimal TZif v2 file: its transitions, if it
+// has any, and the POSIX TZ rule as the footer that time.Location defers to
+// after the last of them (for every lookup, if there are none).
+func tzif(zone string) []byte {
+	rule, trans, _ := strings.Cut(zone, " ")
+	var times, idx, types, chars []byte
+	var seen []string // each zone type's "utoff:isdst:abbr", in type order
+	for _, tr := range strings.Fields(trans) {
+		at, ty, _ := strings.Cut(tr, ":") // ty is "utoff:isdst:abbr"
+		sec, _ := strconv.ParseInt(at, 10, 64)
+		i := 0
+		for i < len(seen) && seen[i] != ty {
+			i++
+		}
+		if i == len(seen) {
+			seen = append(seen, ty)
+			f := strings.SplitN(ty, ":", 3)
+			off, _ := strconv.Atoi(f[0])
+			types = binary.BigEndian.AppendUint32(types, uint32(int32(off)))
+			types = append(types, f[1][0]-'0', byte(len(chars)))
+			chars = append(append(chars, f[2]...), 0)
+		}
+		times = binary.BigEndian.AppendUint64(times, uint64(sec))
+		idx = append(idx, byte(i))
+	}
+	if len(seen) == 0 { // one placeholder zone: utoff=0, isdst=0, abbrind=0, an empty abbreviation
+		seen, types, chars = []string{""}, []byte{0, 0, 0, 0, 0, 0}, []byte{0}
+	}
+	hdr := func(b []byte, ntime, nzone, nchar int) []byte {
+		b = append(b, "TZif2"...)
+		b = append(b, make([]byte, 15)...)
+		// isutcnt, isstdcnt, leapcnt, timecnt, typecnt, charcnt (big-endian u32)
+		for _, n := range [6]int{0, 0, 0, ntime, nzone, nchar} {
+			b = binary.BigEndian.AppendUint32(b, uint32(n))
+		}
+		return b
+	}
+	b := make([]byte, 0, 2*44+len(times)+len(idx)+len(types)+len(chars)+len(rule)+2)
+	b = hdr(b, 0, 0, 0) // the v1 block is skipped by readers of v2
+	b = hdr(b, len(idx), len(seen), len(chars))
+	b = append(append(append(append(b, times...), idx...), types...), chars...)
+	b = append(b, '\n')
+	b = append(b, rule...)
+	return append(b, '\n')
+}
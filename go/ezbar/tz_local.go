@@ -0,0 +1,20 @@
+//go:build !(ezbar_wit_v1 || ezbar_wit_v2 || ezbar_wit_v3 || ezbar_wit_v4)
+
+package ezbar
+
+import "time"
+
+// Local is the machine's local timezone, for time.Now().In(ezbar.Local()).
+//
+// The wasip2 sandbox has no /etc/localtime or TZ, so a plain time.Now() formats
+// in UTC. Local asks the host for the zone name once ([CtxV5.LocalTimezone]) and
+// resolves it against a trimmed tz database embedded in the SDK: each zone's
+// rule, plus its transitions from when the SDK was built on where the rule
+// doesn't predict them, and no history — right for "now", possibly off for
+// timestamps from before that. Unknown names fall back to UTC. The
+// first call is a host call, so make it from Update, not View; a native build
+// asks again after [UseHost] installs another host.
+func Local() *time.Location {
+	localZone.Do(func() { localZone.loc = LoadLocation(exportCtx.LocalTimezone()) })
+	return localZone.loc
+}
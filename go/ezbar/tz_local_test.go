@@ -0,0 +1,36 @@
+//go:build !wasm && !(ezbar_wit_v1 || ezbar_wit_v2 || ezbar_wit_v3 || ezbar_wit_v4)
+
+package ezbar
+
+import (
+	"testing"
+	"time"
+)
+
+// zoneHost is a stubHost in the zone it names.
+type zoneHost struct {
+	stubHost
+	zone string
+}
+
+func (h zoneHost) LocalTimezone() string { return h.zone }
+
+// TestLocalPerHost checks Local asks each host UseHost installs, not only
+// the first one in the process.
+func TestLocalPerHost(t *testing.T) {
+	defer UseHost(nil)
+	at := time.Date(2026, time.July, 15, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		zone string
+		off  int
+	}{
+		{"Asia/Kolkata", 5*3600 + 1800},
+		{"Europe/Berlin", 2 * 3600},
+		{"UTC", 0},
+	} {
+		UseHost(zoneHost{zone: tt.zone})
+		if _, off := at.In(Local()).Zone(); off != tt.off {
+			t.Errorf("Local() under a host in %s: offset %d, want %d", tt.zone, off, tt.off)
+		}
+	}
+}
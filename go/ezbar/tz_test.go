@@ -0,0 +1,113 @@
+package ezbar
+
+import (
+	"archive/zip"
+	"io"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// TestLoadLocation checks the embedded table against the database it's
+// generated from, the toolchain's lib/time/zoneinfo.zip: the machine's own
+// may be an older or newer tz release. Run go generate after a toolchain
+// upgrade that brings a new one.
+func TestLoadLocation(t *testing.T) {
+	zr, err := zip.OpenReader(filepath.Join(runtime.GOROOT(), "lib", "time", "zoneinfo.zip"))
+	if err != nil {
+		t.Skip("no toolchain zoneinfo.zip:", err)
+	}
+	defer zr.Close()
+	reference := func(name string) *time.Location {
+		t.Helper()
+		f, err := zr.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		data, err := io.ReadAll(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loc, err := time.LoadLocationFromTZData(name, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return loc
+	}
+	tests := []struct {
+		zone string
+		at   time.Time // UTC
+	}{
+		{"Europe/Berlin", time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC)},
+		{"Europe/Berlin", time.Date(2026, time.July, 15, 12, 0, 0, 0, time.UTC)},
+		// America/New_York springs forward at 2026-03-08 07:00 UTC.
+		{"America/New_York", time.Date(2026, time.March, 8, 6, 59, 59, 0, time.UTC)},
+		{"America/New_York", time.Date(2026, time.March, 8, 7, 0, 0, 0, time.UTC)},
+		// ... and falls back at 2026-11-01 06:00 UTC.
+		{"America/New_York", time.Date(2026, time.November, 1, 5, 59, 59, 0, time.UTC)},
+		{"America/New_York", time.Date(2026, time.November, 1, 6, 0, 0, 0, time.UTC)},
+		{"Asia/Kolkata", time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC)},
+		{"Asia/Kolkata", time.Date(2026, time.July, 15, 12, 0, 0, 0, time.UTC)},
+		{"Australia/Sydney", time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC)},
+		{"Australia/Sydney", time.Date(2026, time.July, 15, 12, 0, 0, 0, time.UTC)},
+		{"Africa/Casablanca", time.Date(2026, time.October, 15, 12, 0, 0, 0, time.UTC)},
+		{"Africa/Casablanca", time.Date(2027, time.February, 20, 12, 0, 0, 0, time.UTC)},
+		// zones whose coming transitions their rule doesn't predict, so the
+		// table carries them: Vancouver stays on MST from 2026-11-01, and
+		// Gaza's DST follows Ramadan.
+		{"America/Vancouver", time.Date(2026, time.July, 15, 12, 0, 0, 0, time.UTC)},
+		{"America/Vancouver", time.Date(2027, time.July, 15, 12, 0, 0, 0, time.UTC)},
+		{"Asia/Gaza", time.Date(2027, time.March, 15, 12, 0, 0, 0, time.UTC)},
+		{"Asia/Gaza", time.Date(2027, time.August, 15, 12, 0, 0, 0, time.UTC)},
+		{"Asia/Gaza", time.Date(2028, time.February, 15, 12, 0, 0, 0, time.UTC)},
+		{"UTC", time.Date(2026, time.July, 15, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if _, ok := zoneEntry(tt.zone); !ok {
+			t.Errorf("%s: not in the embedded table", tt.zone)
+			continue
+		}
+		want := reference(tt.zone)
+		gotName, gotOff := tt.at.In(LoadLocation(tt.zone)).Zone()
+		wantName, wantOff := tt.at.In(want).Zone()
+		if gotName != wantName || gotOff != wantOff {
+			t.Errorf("%s at %v: got %s %+d, want %s %+d", tt.zone, tt.at, gotName, gotOff, wantName, wantOff)
+		}
+	}
+}
+
+// TestTZifTransitions checks an entry with transitions the rule doesn't
+// predict: Casablanca as tz 2025b has it, +01 but for +00 over Ramadan.
+func TestTZifTransitions(t *testing.T) {
+	const entry = "<+01>-1 1774144800:3600:0:+01 1801965600:0:1:+00 1804989600:3600:0:+01"
+	loc, err := time.LoadLocationFromTZData("Africa/Casablanca", tzif(entry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		at   int64
+		name string
+		off  int
+	}{
+		{1774144800, "+01", 3600},
+		{1801965599, "+01", 3600},
+		{1801965600, "+00", 0},
+		{1804989599, "+00", 0},
+		{1804989600, "+01", 3600},
+		{1900000000, "+01", 3600}, // past the last: the rule
+	} {
+		if name, off := time.Unix(tt.at, 0).In(loc).Zone(); name != tt.name || off != tt.off {
+			t.Errorf("at %d: got %s %+d, want %s %+d", tt.at, name, off, tt.name, tt.off)
+		}
+	}
+}
+
+func TestLoadLocationUnknown(t *testing.T) {
+	for _, name := range []string{"", "Nowhere/Atlantis", "Europe/"} {
+		if loc := LoadLocation(name); loc != time.UTC {
+			t.Errorf("LoadLocation(%q) = %v, want UTC", name, loc)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package ezbar
+
+import (
+	"errors"
+	"strconv"
+	"unicode/utf8"
+)
+
+// The host's caps on a lowered render tree (crates/ezbar-wasm, RFC 0006 §1a).
+// Past MaxNodes the host rejects the whole tree (a blank chip); a node deeper
+// than MaxDepth renders as "…"; a mouse-area id is cut to MaxIDLen characters,
+// so pointer events come back under the cut id.
+const (
+	MaxNodes = 2000
+	MaxDepth = 32
+	MaxIDLen = 64
+)
+
+// Validate checks r against [MaxNodes], [MaxDepth] and [MaxIDLen], and names
+// the path to the first offending node, e.g.
+//
+//	row > [1] mouse-area("chip") > text: depth 33 exceeds MaxDepth (32)
+//
+// The root is depth 0, and nodes count in the order lower emits them (children
+// first). Call it from a unit test on your View/Popup; [Debug] runs it live.
+func (r Render) Validate() error {
+	var v validator
+	v.walk(r, r.label(), 0)
+	return v.err
+}
+
+type validator struct {
+	n   int // nodes emitted so far
+	err error
+}
+
+func (v *validator) walk(r Render, path string, depth int) bool {
+	if depth > MaxDepth {
+		return v.fail(path, "depth "+strconv.Itoa(depth)+" exceeds MaxDepth ("+strconv.Itoa(MaxDepth)+")")
+	}
+	if r.kind == kMouseArea && utf8.RuneCountInString(r.hitID) > MaxIDLen {
+		return v.fail(path, "id is "+strconv.Itoa(utf8.RuneCountInString(r.hitID))+" characters, over MaxIDLen ("+strconv.Itoa(MaxIDLen)+")")
+	}
+	for i, k := range r.kids {
+		seg := k.label()
+		if r.kind == kRow || r.kind == kColumn {
+			seg = "[" + strconv.Itoa(i) + "] " + seg
+		}
+		if !v.walk(k, path+" > "+seg, depth+1) {
+			return false
+		}
+	}
+	if v.n++; v.n > MaxNodes {
+		return v.fail(path, "node "+strconv.Itoa(v.n)+" exceeds MaxNodes ("+strconv.Itoa(MaxNodes)+")")
+	}
+	return true
+}
+
+func (v *validator) fail(path, msg string) bool {
+	v.err = errors.New(path + ": " + msg)
+	return false
+}
+
+// label names r by its WIT node kind, plus the id of a mouse-area.
+func (r Render) label() string {
+	if r.kind == kMouseArea {
+		return "mouse-area(" + strconv.Quote(r.hitID) + ")"
+	}
+	return r.kindName()
+}
@@ -40,13 +40,31 @@ func (c Color) paint() ui.Paint {
 	return types.PaintToken(c.tok)
 }
 
+func colorOf(p types.Paint) Color {
+	if rgba := p.Rgba(); rgba != nil {
+		return Color{isRGBA: true, rgba: *rgba}
+	}
+	return Color{tok: *p.Token()}
+}
+
+// ── sizing ──────────────────────────────────────────────────────────────────
+
+// emPx is the bar's text size, refreshed from the host on init and on every
+// EvConfig; 14 (the host default) until then.
+var emPx float32 = 14
+
+// Em is n times the bar's text size in px, so icons and spacers scale with the
+// user's text_size: ezbar.IconCloud.View(ezbar.Em(1), ezbar.Fg). Unlike
+// [CtxV1.TextSize] it needs no Ctx, so it's fine to call from View.
+func Em(n float32) float32 { return n * emPx }
+
 // ── components ──────────────────────────────────────────────────────────────
 
 // Icon is one of the host's embedded icons. Render it with [Icon.View]. The
 // values are kept in lock-step with the WIT icon-id enum (same order).
 type Icon uint8
 
-// The host icon set. Use like: ezbar.IconCloud.View(14, ezbar.Fg).
+// The host icon set. Use like: ezbar.IconCloud.View(ezbar.Em(1), ezbar.Fg).
 const (
 	IconCPU Icon = iota
 	IconMemory
@@ -177,8 +195,9 @@ type Render struct {
 	height  float32 // chart height
 	spacing float32 // row/column
 	align   Align
-	padding float32 // container
-	hitID   string  // mouse-area id
+	padding float32        // container
+	hitID   string         // mouse-area id
+	onPtr   PointerHandler // OnClick's handler; hitID is assigned by Handlers
 	kids    []Render
 }
 
@@ -200,10 +219,27 @@ func Container(child Render) Render { return Render{kind: kContainer, kids: []Re
 
 // MouseArea makes its child interactive: the host sends EvPointer events tagged
 // with id to your Update. Not needed for the hover popup — that is automatic.
+// [OnClick] does the same with a handler in place of the id.
 func MouseArea(id string, child Render) Render {
 	return Render{kind: kMouseArea, hitID: id, kids: []Render{child}}
 }
 
+// OnClick makes its child interactive like [MouseArea], but its pointer events
+// go to h instead of Update, so there's no id to keep in step between View
+// and Update:
+//
+//	ezbar.OnClick(ezbar.Text(w.temp), func(k ezbar.PointerKind, _ float32) bool {
+//		w.compact = !w.compact
+//		return true
+//	})
+//
+// The SDK gives the area a short id when the tree goes to the host (see
+// [Handlers]); it stays the same from one View to the next as long as the
+// tree keeps its shape.
+func OnClick(child Render, h PointerHandler) Render {
+	return Render{kind: kMouseArea, onPtr: h, kids: []Render{child}}
+}
+
 // Spacer is fixed empty horizontal space.
 func Spacer(px float32) Render { return Render{kind: kSpacer, width: px} }
 
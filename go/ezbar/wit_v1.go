@@ -0,0 +1,6 @@
+//go:build ezbar_wit_v1
+
+package ezbar
+
+// Ctx is [CtxV1]: this build targets WIT v0.1.0 (build tag ezbar_wit_v1).
+type Ctx = CtxV1
@@ -0,0 +1,6 @@
+//go:build ezbar_wit_v2
+
+package ezbar
+
+// Ctx is [CtxV2]: this build targets WIT v0.2.0 (build tag ezbar_wit_v2).
+type Ctx = CtxV2
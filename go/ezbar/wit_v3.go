@@ -0,0 +1,6 @@
+//go:build ezbar_wit_v3
+
+package ezbar
+
+// Ctx is [CtxV3]: this build targets WIT v0.3.0 (build tag ezbar_wit_v3).
+type Ctx = CtxV3
@@ -0,0 +1,6 @@
+//go:build ezbar_wit_v4
+
+package ezbar
+
+// Ctx is [CtxV4]: this build targets WIT v0.4.0 (build tag ezbar_wit_v4).
+type Ctx = CtxV4
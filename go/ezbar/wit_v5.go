@@ -0,0 +1,6 @@
+//go:build ezbar_wit_v5
+
+package ezbar
+
+// Ctx is [CtxV5]: this build targets WIT v0.5.0 (build tag ezbar_wit_v5).
+type Ctx = CtxV5
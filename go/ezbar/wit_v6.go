@@ -0,0 +1,7 @@
+//go:build ezbar_wit_v6 || !(ezbar_wit_v1 || ezbar_wit_v2 || ezbar_wit_v3 || ezbar_wit_v4 || ezbar_wit_v5)
+
+package ezbar
+
+// Ctx is [CtxV6]: this build targets WIT v0.6.0, the latest — the default
+// when no ezbar_wit_v* tag is set.
+type Ctx = CtxV6
@@ -2,4 +2,9 @@ module github.com/birdayz/ezbar/go
 
 go 1.23.0
 
-require go.bytecodealliance.org/cm v0.3.0
+require (
+	go.bytecodealliance.org/cm v0.3.0
+	golang.org/x/image v0.25.0
+)
+
+require golang.org/x/text v0.23.0 // indirect
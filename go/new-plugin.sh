@@ -10,6 +10,9 @@ dir="$root/examples/$name"
 [ -e "$dir" ] && { echo "error: $dir already exists" >&2; exit 1; }
 # CamelCase the type name from a kebab/snake name
 ty="$(echo "$name" | sed -E 's/[-_]+/ /g' | awk '{for(i=1;i<=NF;i++)$i=toupper(substr($i,1,1)) substr($i,2)}1' | tr -d ' ')"
+# The WIT version to start at (ezbar:plugin@0.N.0): the manifest's `wit` and the
+# README's manual build command both come from it.
+wit=1
 mkdir -p "$dir"
 
 cat > "$dir/main.go" <<EOF
@@ -38,7 +41,7 @@ func (p *$ty) Update(ctx ezbar.Ctx, ev ezbar.Event) bool {
 // View is pure + synchronous: build the chip from the widget DSL.
 func (p *$ty) View() ezbar.Render {
 	return ezbar.Row(
-		ezbar.IconDot.View(14, ezbar.Accent),
+		ezbar.IconDot.View(ezbar.Em(1), ezbar.Accent),
 		ezbar.Text(p.label),
 	).Spacing(5)
 }
@@ -65,8 +68,13 @@ if go version | grep -qE 'go1\.(2[5-9]|[3-9][0-9])'; then
   fi
   export GOROOT="$sdk"; export PATH="$GOROOT/bin:$HOME/go/bin:$PATH"
 fi
-gofmt -w . >/dev/null; go vet . || true
-tinygo build -target=wasip2 -o "$name.wasm" --wit-package ../../wit --wit-world plugin-guest .
+# The manifest's `wit` picks the WIT version: ezbar:plugin@0.N.0 -> SDK tag ezbar_wit_vN
+# + guest world plugin-guest-vN (a Ctx method newer than that is a compile error).
+n="$(sed -nE 's/^wit *= *"0\.([0-9]+)\.0".*/\1/p' ezbar-plugin.toml)"
+[ -n "$n" ] || { echo "error: no wit = \"0.N.0\" in ezbar-plugin.toml" >&2; exit 1; }
+gofmt -w . >/dev/null; go vet -tags "ezbar_wit_v$n" . || true
+tinygo build -target=wasip2 -tags "ezbar_wit_v$n" -o "$name.wasm" \
+  --wit-package ../../wit --wit-world "plugin-guest-v$n" .
 echo "built $(pwd)/$name.wasm"
 echo "preview: (cd ../../.. && cargo run -p ezbar-wasm --example preview -- go/examples/$name/$name.wasm --check)"
 echo "package: ezbar package $name.wasm   # embed ezbar-plugin.toml + print the registry entry"
@@ -81,15 +89,16 @@ cat > "$dir/ezbar-plugin.toml" <<EOF
 id = "$name"
 name = "$ty"
 version = "0.1.0"
-wit = "0.1.0"          # bump to "0.2.0" if you use ctx.sway_snapshot()
+wit = "0.$wit.0"          # the oldest WIT with what you call; build.sh builds against it
 # publisher = "your-handle"
 description = "TODO: one line."
 
 [capabilities]
 # Grant only what you actually call; the host enforces these per-call, sandboxed.
-# network = ["api.example.com"]   # for ctx.http_get (host allow-list)
+# network = ["api.example.com"]   # for ctx.HTTPGet / ctx.HTTPOpen (host allow-list)
 # feeds   = ["cpu"]               # cpu/memory/temperature/battery/net (ctx.feed_subscribe)
-# sway    = false                 # read-only workspace list + title (ctx.sway_snapshot)
+# sway    = false                 # read-only workspace list + title (ctx.SwaySnapshot)
+# exec    = ["kubectl"]           # allow-listed programs for ctx.Exec (dangerous tier, RFC 0015)
 EOF
 
 cat > "$dir/README.md" <<EOF
@@ -111,13 +120,19 @@ cat > "$dir/README.md" <<EOF
 
 ## Build the component
 
+For \`wit = "0.$wit.0"\` (after bumping \`wit\`, bump the tag and world to match):
+
 \`\`\`sh
 cd $(basename "$root")/examples/$name
-tinygo build -target=wasip2 -o $name.wasm --wit-package ../../wit --wit-world plugin-guest .
+tinygo build -target=wasip2 -tags ezbar_wit_v$wit -o $name.wasm \\
+    --wit-package ../../wit --wit-world plugin-guest-v$wit .
 \`\`\`
 
-The \`../../wit\` guest world (shared infra) unions the WASI imports TinyGo needs
-with the ezbar plugin world — you don't touch it.
+The \`../../wit\` guest worlds (shared infra) union the WASI imports TinyGo needs
+with one version of the ezbar plugin world — you don't touch them. The tag and
+world pick that version (\`ezbar_wit_vN\` + \`plugin-guest-vN\` = v0.N.0) and must
+match \`wit\` in \`ezbar-plugin.toml\`; \`build.sh\` derives both from it. Calling a
+\`Ctx\` method newer than your version fails to compile — bump \`wit\` to use it.
 
 ## Preview & install
 